@@ -2,32 +2,255 @@ package api
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/rafimuhammad01/portofolio-api/internal/analytics"
+	"github.com/rafimuhammad01/portofolio-api/internal/archive"
+	"github.com/rafimuhammad01/portofolio-api/internal/card"
+	"github.com/rafimuhammad01/portofolio-api/internal/feed"
+	"github.com/rafimuhammad01/portofolio-api/internal/inquiry"
 	"github.com/rafimuhammad01/portofolio-api/internal/jwt"
+	"github.com/rafimuhammad01/portofolio-api/internal/link"
+	"github.com/rafimuhammad01/portofolio-api/internal/member"
+	"github.com/rafimuhammad01/portofolio-api/internal/photo"
+	"github.com/rafimuhammad01/portofolio-api/internal/project"
+	"github.com/rafimuhammad01/portofolio-api/internal/reaction"
+	"github.com/rafimuhammad01/portofolio-api/internal/resume"
+	"github.com/rafimuhammad01/portofolio-api/internal/revision"
+	"github.com/rafimuhammad01/portofolio-api/internal/role"
+	"github.com/rafimuhammad01/portofolio-api/internal/search"
+	"github.com/rafimuhammad01/portofolio-api/internal/sitemap"
+	"github.com/rafimuhammad01/portofolio-api/internal/skill"
+	"github.com/rafimuhammad01/portofolio-api/internal/storage"
+	"github.com/rafimuhammad01/portofolio-api/internal/tag"
+	"github.com/rafimuhammad01/portofolio-api/internal/testimonial"
+	"github.com/rafimuhammad01/portofolio-api/internal/translation"
+	"github.com/rafimuhammad01/portofolio-api/internal/trash"
 	userpkg "github.com/rafimuhammad01/portofolio-api/internal/user"
 	"github.com/rafimuhammad01/portofolio-api/middleware"
 )
 
 type Routes struct {
-	Router      *gin.Engine
-	userHandler *userpkg.Handler
-	jwtHandler  *jwt.Handler
+	Router             *gin.Engine
+	userHandler        *userpkg.Handler
+	jwtHandler         *jwt.Handler
+	memberHandler      *member.Handler
+	projectHandler     *project.Handler
+	searchHandler      *search.Handler
+	skillHandler       *skill.Handler
+	roleHandler        *role.Handler
+	photoHandler       *photo.Handler
+	trashHandler       *trash.Handler
+	tagHandler         *tag.Handler
+	inquiryHandler     *inquiry.Handler
+	testimonialHandler *testimonial.Handler
+	storageHandler     *storage.Handler
+	translationHandler *translation.Handler
+	revisionHandler    *revision.Handler
+	archiveHandler     *archive.Handler
+	feedHandler        *feed.Handler
+	sitemapHandler     *sitemap.Handler
+	cardHandler        *card.Handler
+	resumeHandler      *resume.Handler
+	analyticsHandler   *analytics.Handler
+	reactionHandler    *reaction.Handler
+	linkHandler        *link.Handler
 }
 
-func NewRoutes(router *gin.Engine, userHandler *userpkg.Handler, jwtHandler *jwt.Handler) *Routes {
+func NewRoutes(
+	router *gin.Engine,
+	userHandler *userpkg.Handler,
+	jwtHandler *jwt.Handler,
+	memberHandler *member.Handler,
+	projectHandler *project.Handler,
+	searchHandler *search.Handler,
+	skillHandler *skill.Handler,
+	roleHandler *role.Handler,
+	photoHandler *photo.Handler,
+	trashHandler *trash.Handler,
+	tagHandler *tag.Handler,
+	inquiryHandler *inquiry.Handler,
+	testimonialHandler *testimonial.Handler,
+	storageHandler *storage.Handler,
+	translationHandler *translation.Handler,
+	revisionHandler *revision.Handler,
+	archiveHandler *archive.Handler,
+	feedHandler *feed.Handler,
+	sitemapHandler *sitemap.Handler,
+	cardHandler *card.Handler,
+	resumeHandler *resume.Handler,
+	analyticsHandler *analytics.Handler,
+	reactionHandler *reaction.Handler,
+	linkHandler *link.Handler,
+) *Routes {
 	return &Routes{
-		Router:      router,
-		userHandler: userHandler,
-		jwtHandler:  jwtHandler,
+		Router:             router,
+		userHandler:        userHandler,
+		jwtHandler:         jwtHandler,
+		memberHandler:      memberHandler,
+		projectHandler:     projectHandler,
+		searchHandler:      searchHandler,
+		skillHandler:       skillHandler,
+		roleHandler:        roleHandler,
+		photoHandler:       photoHandler,
+		trashHandler:       trashHandler,
+		tagHandler:         tagHandler,
+		inquiryHandler:     inquiryHandler,
+		testimonialHandler: testimonialHandler,
+		storageHandler:     storageHandler,
+		translationHandler: translationHandler,
+		revisionHandler:    revisionHandler,
+		archiveHandler:     archiveHandler,
+		feedHandler:        feedHandler,
+		sitemapHandler:     sitemapHandler,
+		cardHandler:        cardHandler,
+		resumeHandler:      resumeHandler,
+		analyticsHandler:   analyticsHandler,
+		reactionHandler:    reactionHandler,
+		linkHandler:        linkHandler,
 	}
 }
 
 func (r *Routes) Init() {
+	// Crawlers look for these at the root of the site, which proxies them here
+	r.Router.GET("/robots.txt", r.sitemapHandler.GetRobots)
+	r.Router.GET("/sitemap.xml", r.sitemapHandler.GetSitemap)
+	r.Router.GET("/sitemaps/:page", r.sitemapHandler.GetSitemapPage)
+
 	v1 := r.Router.Group("/api/v1")
 
 	// User Routing
 	user := v1.Group("/user")
+	user.GET("", middleware.AuthMiddleware(r.jwtHandler), r.userHandler.GetAllUser)
 	user.POST("/register", r.userHandler.RegisterUser)
 	user.GET("/me", middleware.AuthMiddleware(r.jwtHandler), r.userHandler.GetUserByID)
 	user.POST("/login", r.userHandler.Login)
 	user.POST("/refresh-token", r.userHandler.RefreshToken)
+
+	// Member Routing
+	members := v1.Group("/members")
+	members.GET("", r.memberHandler.GetAllMember)
+	members.GET("/:id", middleware.OptionalAuthMiddleware(r.jwtHandler), r.memberHandler.GetMemberByID)
+	members.GET("/:id/card.png", r.cardHandler.GetMemberCard)
+	members.GET("/:id/resume.json", r.resumeHandler.GetMemberResumeJSON)
+	members.GET("/:id/resume.pdf", r.resumeHandler.GetMemberResumePDF)
+	members.POST("/:id/views", r.analyticsHandler.RecordView(analytics.TypeMember))
+	members.POST("", middleware.AuthMiddleware(r.jwtHandler), r.memberHandler.CreateMember)
+	members.PUT("/order", middleware.AuthMiddleware(r.jwtHandler), r.memberHandler.ReorderMember)
+	members.PUT("/:id", middleware.AuthMiddleware(r.jwtHandler), r.memberHandler.UpdateMember)
+	members.PUT("/:id/photo", middleware.AuthMiddleware(r.jwtHandler), r.memberHandler.UploadMemberPhoto)
+	members.POST("/:id/revisions/:revision_id/revert", middleware.AuthMiddleware(r.jwtHandler), r.memberHandler.RevertMember)
+	members.DELETE("/:id", middleware.AuthMiddleware(r.jwtHandler), r.trashHandler.MoveToTrash(trash.TypeMember))
+
+	// Project Routing
+	projects := v1.Group("/projects")
+	projects.GET("", middleware.OptionalAuthMiddleware(r.jwtHandler), r.projectHandler.GetAllProject)
+	projects.GET("/:id", middleware.OptionalAuthMiddleware(r.jwtHandler), r.projectHandler.GetProjectByID)
+	projects.GET("/:id/card.png", r.cardHandler.GetProjectCard)
+	projects.POST("/:id/views", r.analyticsHandler.RecordView(analytics.TypeProject))
+	projects.GET("/:id/reactions", r.reactionHandler.GetReactions)
+	projects.PUT("/:id/reactions/:kind", r.reactionHandler.React)
+	projects.DELETE("/:id/reactions/:kind", r.reactionHandler.Unreact)
+	projects.POST("", middleware.AuthMiddleware(r.jwtHandler), r.projectHandler.CreateProject)
+	projects.PUT("/order", middleware.AuthMiddleware(r.jwtHandler), r.projectHandler.ReorderProject)
+	projects.PUT("/:id", middleware.AuthMiddleware(r.jwtHandler), r.projectHandler.UpdateProject)
+	projects.PATCH("/:id/status", middleware.AuthMiddleware(r.jwtHandler), r.projectHandler.UpdateProjectStatus)
+	projects.POST("/:id/revisions/:revision_id/revert", middleware.AuthMiddleware(r.jwtHandler), r.projectHandler.RevertProject)
+	projects.DELETE("/:id", middleware.AuthMiddleware(r.jwtHandler), r.trashHandler.MoveToTrash(trash.TypeProject))
+	projects.POST("/:id/members", middleware.AuthMiddleware(r.jwtHandler), r.projectHandler.AssignMember)
+	projects.DELETE("/:id/members/:member_id", middleware.AuthMiddleware(r.jwtHandler), r.projectHandler.UnassignMember)
+	projects.POST("/:id/photos", middleware.AuthMiddleware(r.jwtHandler), r.photoHandler.UploadPhoto)
+	projects.POST("/:id/links", middleware.AuthMiddleware(r.jwtHandler), r.linkHandler.CreateLink)
+	projects.POST("/:id/tags", middleware.AuthMiddleware(r.jwtHandler), r.projectHandler.AssignTag)
+	projects.DELETE("/:id/tags/:tag_id", middleware.AuthMiddleware(r.jwtHandler), r.projectHandler.UnassignTag)
+
+	// Tag Routing
+	tags := v1.Group("/tags")
+	tags.GET("", r.tagHandler.GetAllTag)
+	tags.POST("", middleware.AuthMiddleware(r.jwtHandler), r.tagHandler.CreateTag)
+	tags.PUT("/:id", middleware.AuthMiddleware(r.jwtHandler), r.tagHandler.UpdateTag)
+	tags.DELETE("/:id", middleware.AuthMiddleware(r.jwtHandler), r.tagHandler.DeleteTag)
+
+	// Skill Routing
+	skills := v1.Group("/skills")
+	skills.GET("", r.skillHandler.GetAllSkill)
+	skills.PUT("/:id", middleware.AuthMiddleware(r.jwtHandler), r.skillHandler.UpdateSkill)
+	skills.POST("/:id/revisions/:revision_id/revert", middleware.AuthMiddleware(r.jwtHandler), r.skillHandler.RevertSkill)
+	skills.POST("/:id/endorsements", middleware.AuthMiddleware(r.jwtHandler), r.skillHandler.EndorseSkill)
+	skills.DELETE("/:id/endorsements", middleware.AuthMiddleware(r.jwtHandler), r.skillHandler.UnendorseSkill)
+	skills.DELETE("/:id", middleware.AuthMiddleware(r.jwtHandler), r.trashHandler.MoveToTrash(trash.TypeSkill))
+
+	// Role Routing
+	roles := v1.Group("/roles")
+	roles.GET("", r.roleHandler.GetAllRole)
+	roles.PUT("/:id", middleware.AuthMiddleware(r.jwtHandler), r.roleHandler.UpdateRole)
+	roles.POST("/:id/revisions/:revision_id/revert", middleware.AuthMiddleware(r.jwtHandler), r.roleHandler.RevertRole)
+	roles.DELETE("/:id", middleware.AuthMiddleware(r.jwtHandler), r.trashHandler.MoveToTrash(trash.TypeRole))
+
+	// Photo Routing
+	photos := v1.Group("/photos")
+	photos.GET("", r.photoHandler.GetAllPhoto)
+	photos.DELETE("/:id", middleware.AuthMiddleware(r.jwtHandler), r.trashHandler.MoveToTrash(trash.TypePhoto))
+
+	// Link Routing
+	links := v1.Group("/links", middleware.AuthMiddleware(r.jwtHandler))
+	links.GET("", r.linkHandler.GetAllLink)
+	links.GET("/report", r.linkHandler.GetLinkReport)
+	links.PUT("/:id", r.linkHandler.UpdateLink)
+	links.DELETE("/:id", r.linkHandler.DeleteLink)
+	links.POST("/:id/check", r.linkHandler.CheckLink)
+
+	// Media Routing
+	v1.GET("/media/*key", r.storageHandler.ServeFile)
+	v1.HEAD("/media/*key", r.storageHandler.ServeFile)
+
+	// Translation Routing
+	translations := v1.Group("/translations", middleware.AuthMiddleware(r.jwtHandler))
+	translations.GET("/:type/:id", r.translationHandler.GetAllTranslation)
+	translations.PUT("/:type/:id/:locale", r.translationHandler.SetTranslation)
+	translations.DELETE("/:type/:id/:locale", r.translationHandler.DeleteTranslation)
+
+	// Revision Routing
+	revisions := v1.Group("/revisions", middleware.AuthMiddleware(r.jwtHandler))
+	revisions.GET("/:type/:id", r.revisionHandler.GetAllRevision)
+	revisions.GET("/:type/:id/diff", r.revisionHandler.GetRevisionDiff)
+
+	// Trash Routing
+	trashItems := v1.Group("/trash", middleware.AuthMiddleware(r.jwtHandler))
+	trashItems.GET("", r.trashHandler.GetAllItem)
+	trashItems.POST("/:type/:id/restore", r.trashHandler.RestoreItem)
+	trashItems.DELETE("/:type/:id", r.trashHandler.PurgeItem)
+
+	// Testimonial Routing
+	testimonials := v1.Group("/testimonials")
+	testimonials.GET("", middleware.OptionalAuthMiddleware(r.jwtHandler), r.testimonialHandler.GetAllTestimonial)
+	testimonials.POST("", r.testimonialHandler.SubmitTestimonial)
+	testimonials.PATCH("/:id/status", middleware.AuthMiddleware(r.jwtHandler), r.testimonialHandler.ModerateTestimonial)
+	testimonials.DELETE("/:id", middleware.AuthMiddleware(r.jwtHandler), r.testimonialHandler.DeleteTestimonial)
+
+	// Inquiry Routing
+	inquiries := v1.Group("/inquiries")
+	inquiries.GET("/form-token", r.inquiryHandler.GetFormToken)
+	inquiries.POST("", r.inquiryHandler.SubmitInquiry)
+	inquiries.GET("", middleware.AuthMiddleware(r.jwtHandler), r.inquiryHandler.GetAllInquiry)
+	inquiries.GET("/:id", middleware.AuthMiddleware(r.jwtHandler), r.inquiryHandler.GetInquiryByID)
+	inquiries.PATCH("/:id/status", middleware.AuthMiddleware(r.jwtHandler), r.inquiryHandler.UpdateInquiryStatus)
+	inquiries.POST("/:id/notes", middleware.AuthMiddleware(r.jwtHandler), r.inquiryHandler.AddNote)
+
+	// Archive Routing
+	archives := v1.Group("/archive", middleware.AuthMiddleware(r.jwtHandler))
+	archives.GET("/export", r.archiveHandler.ExportArchive)
+	archives.POST("/import", r.archiveHandler.ImportArchive)
+
+	// Feed Routing
+	feeds := v1.Group("/feeds")
+	feeds.GET("/projects.atom", r.feedHandler.GetProjectsAtom)
+	feeds.GET("/projects.rss", r.feedHandler.GetProjectsRSS)
+	feeds.GET("/projects.json", r.feedHandler.GetProjectsJSON)
+
+	// Analytics Routing
+	analyticsGroup := v1.Group("/analytics", middleware.AuthMiddleware(r.jwtHandler))
+	analyticsGroup.GET("/views", r.analyticsHandler.GetSeries)
+	analyticsGroup.GET("/top", r.analyticsHandler.GetTop)
+
+	// Search Routing
+	v1.GET("/search", r.searchHandler.Search)
 }
@@ -4,9 +4,36 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/rafimuhammad01/portofolio-api/db/postgres"
 	"github.com/rafimuhammad01/portofolio-api/db/redis"
+	analytics2 "github.com/rafimuhammad01/portofolio-api/internal/analytics"
+	archive2 "github.com/rafimuhammad01/portofolio-api/internal/archive"
+	card2 "github.com/rafimuhammad01/portofolio-api/internal/card"
+	feed2 "github.com/rafimuhammad01/portofolio-api/internal/feed"
+	inquiry2 "github.com/rafimuhammad01/portofolio-api/internal/inquiry"
 	jwt2 "github.com/rafimuhammad01/portofolio-api/internal/jwt"
+	link2 "github.com/rafimuhammad01/portofolio-api/internal/link"
+	markdown2 "github.com/rafimuhammad01/portofolio-api/internal/markdown"
+	media2 "github.com/rafimuhammad01/portofolio-api/internal/media"
+	member2 "github.com/rafimuhammad01/portofolio-api/internal/member"
+	photo2 "github.com/rafimuhammad01/portofolio-api/internal/photo"
+	project2 "github.com/rafimuhammad01/portofolio-api/internal/project"
+	reaction2 "github.com/rafimuhammad01/portofolio-api/internal/reaction"
+	resume2 "github.com/rafimuhammad01/portofolio-api/internal/resume"
+	revision2 "github.com/rafimuhammad01/portofolio-api/internal/revision"
+	role2 "github.com/rafimuhammad01/portofolio-api/internal/role"
+	search2 "github.com/rafimuhammad01/portofolio-api/internal/search"
+	sitemap2 "github.com/rafimuhammad01/portofolio-api/internal/sitemap"
+	skill2 "github.com/rafimuhammad01/portofolio-api/internal/skill"
+	storage2 "github.com/rafimuhammad01/portofolio-api/internal/storage"
+	tag2 "github.com/rafimuhammad01/portofolio-api/internal/tag"
+	testimonial2 "github.com/rafimuhammad01/portofolio-api/internal/testimonial"
+	translation2 "github.com/rafimuhammad01/portofolio-api/internal/translation"
+	trash2 "github.com/rafimuhammad01/portofolio-api/internal/trash"
 	user2 "github.com/rafimuhammad01/portofolio-api/internal/user"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
 	"os"
+	"strings"
+	"time"
 )
 
 type Server struct {
@@ -21,24 +48,131 @@ func NewServer(router *gin.Engine) *Server {
 
 var (
 	// Handler
-	userHandler *user2.Handler
-	jwtHandler  *jwt2.Handler
+	userHandler        *user2.Handler
+	jwtHandler         *jwt2.Handler
+	memberHandler      *member2.Handler
+	projectHandler     *project2.Handler
+	searchHandler      *search2.Handler
+	skillHandler       *skill2.Handler
+	roleHandler        *role2.Handler
+	photoHandler       *photo2.Handler
+	trashHandler       *trash2.Handler
+	tagHandler         *tag2.Handler
+	inquiryHandler     *inquiry2.Handler
+	testimonialHandler *testimonial2.Handler
+	storageHandler     *storage2.Handler
+	translationHandler *translation2.Handler
+	revisionHandler    *revision2.Handler
+	archiveHandler     *archive2.Handler
+	feedHandler        *feed2.Handler
+	sitemapHandler     *sitemap2.Handler
+	cardHandler        *card2.Handler
+	resumeHandler      *resume2.Handler
+	analyticsHandler   *analytics2.Handler
+	reactionHandler    *reaction2.Handler
+	linkHandler        *link2.Handler
 
 	// Service
-	userService user2.Service
-	jwtService  jwt2.Service
+	userService        user2.Service
+	jwtService         jwt2.Service
+	memberService      member2.Service
+	projectService     project2.Service
+	searchService      search2.Service
+	skillService       skill2.Service
+	roleService        role2.Service
+	photoService       photo2.Service
+	trashService       trash2.Service
+	tagService         tag2.Service
+	inquiryService     inquiry2.Service
+	testimonialService testimonial2.Service
+	mediaService       media2.Service
+	translationService translation2.Service
+	revisionService    revision2.Service
+	archiveService     archive2.Service
+	feedService        feed2.Service
+	sitemapService     sitemap2.Service
+	cardService        card2.Service
+	resumeService      resume2.Service
+	analyticsService   analytics2.Service
+	reactionService    reaction2.Service
+	markdownService    markdown2.Service
+	linkService        link2.Service
 
 	// Repo
-	userRepo user2.Repo
-	jwtRepo  jwt2.Repo
+	userRepo        user2.Repo
+	jwtRepo         jwt2.Repo
+	memberRepo      member2.Repo
+	projectRepo     project2.Repo
+	searchRepo      search2.Repo
+	skillRepo       skill2.Repo
+	roleRepo        role2.Repo
+	photoRepo       photo2.Repo
+	trashRepo       trash2.Repo
+	tagRepo         tag2.Repo
+	inquiryRepo     inquiry2.Repo
+	testimonialRepo testimonial2.Repo
+	mediaRepo       media2.Repo
+	translationRepo translation2.Repo
+	revisionRepo    revision2.Repo
+	archiveRepo     archive2.Repo
+	feedRepo        feed2.Repo
+	sitemapRepo     sitemap2.Repo
+	cardRepo        card2.Repo
+	resumeRepo      resume2.Repo
+	analyticsRepo   analytics2.Repo
+	reactionRepo    reaction2.Repo
+	linkRepo        link2.Repo
 )
 
 func (s Server) Init() {
+	s.initServices()
+
+	// Start background jobs
+	media2.StartProcessor(mediaService, utils.GetDurationEnv("MEDIA_PROCESS_INTERVAL", 10*time.Second))
+	project2.StartScheduler(projectService, utils.GetProjectPublishInterval())
+	analytics2.StartFlusher(analyticsService, utils.GetDurationEnv("ANALYTICS_FLUSH_INTERVAL", 5*time.Minute))
+	reaction2.StartFlusher(reactionService, utils.GetDurationEnv("REACTION_FLUSH_INTERVAL", 30*time.Second))
+	link2.StartChecker(linkService, utils.GetDurationEnv("LINK_CHECK_INTERVAL", 10*time.Minute))
+
+	s.initRoutes()
+}
+
+// InitRoutes wires up the API without starting background jobs, for commands that only serve requests in process
+func (s Server) InitRoutes() {
+	s.initServices()
+	s.initRoutes()
+}
+
+func (s Server) initServices() {
 	// Init DB
 	db := postgres.Init()
 	rdb := redis.Init()
 
+	// Init blob storage
+	blobStore, err := storage2.NewLocalStore(utils.GetEnv("MEDIA_ROOT", "./uploads"), utils.GetEnv("MEDIA_BASE_URL", "/api/v1/media"))
+	if err != nil {
+		logrus.Fatal("error initializing blob storage: ", err)
+	}
+	storageHandler = storage2.NewHandler(blobStore)
+
+	// Media
+	mediaRepo = media2.NewRepo(db)
+	mediaService = media2.NewService(mediaRepo, blobStore)
+
 	// Init internal package
+	// Translation
+	translationRepo = translation2.NewRepo(db)
+	translationService = translation2.NewService(translationRepo)
+	translationHandler = translation2.NewHandler(translationService)
+
+	// Revision
+	revisionRepo = revision2.NewRepo(db)
+	revisionService = revision2.NewService(revisionRepo)
+	revisionHandler = revision2.NewHandler(revisionService)
+
+	// Markdown
+	markdownService = markdown2.NewService(utils.GetIntEnv("MARKDOWN_CACHE_SIZE", 1000))
+
 	// JWT
 	jwtRepo = jwt2.NewRepo(rdb)
 	jwtService = jwt2.NewService(os.Getenv("JWT_SECRET"), jwtRepo)
@@ -49,11 +183,182 @@ func (s Server) Init() {
 	userService = user2.NewService(userRepo, jwtService)
 	userHandler = user2.NewHandler(userService)
 
-	// Start routing
-	r := NewRoutes(s.Router, userHandler, jwtHandler)
+	// Member
+	memberRepo = member2.NewRepo(db)
+	memberService = member2.NewService(memberRepo, blobStore, mediaService, revisionService)
+	memberHandler = member2.NewHandler(memberService)
+
+	// Project
+	projectRepo = project2.NewRepo(db)
+	projectService = project2.NewService(projectRepo, translationService, revisionService, markdownService, utils.GetBoolEnv("LINK_HIDE_BROKEN", false))
+	projectHandler = project2.NewHandler(projectService)
+
+	// Search
+	searchRepo = search2.NewRepo(db)
+	searchService = search2.NewService(searchRepo)
+	searchHandler = search2.NewHandler(searchService)
+
+	// Skill
+	skillRepo = skill2.NewRepo(db)
+	skillService = skill2.NewService(skillRepo, translationService, revisionService, markdownService)
+	skillHandler = skill2.NewHandler(skillService)
+
+	// Role
+	roleRepo = role2.NewRepo(db)
+	roleService = role2.NewService(roleRepo, translationService, revisionService)
+	roleHandler = role2.NewHandler(roleService)
+
+	// Photo
+	photoRepo = photo2.NewRepo(db)
+	photoService = photo2.NewService(photoRepo, blobStore, mediaService)
+	photoHandler = photo2.NewHandler(photoService)
+
+	// Trash
+	trashRepo = trash2.NewRepo(db)
+	trashService = trash2.NewService(trashRepo, mediaService)
+	trashHandler = trash2.NewHandler(trashService)
+
+	// Tag
+	tagRepo = tag2.NewRepo(db)
+	tagService = tag2.NewService(tagRepo)
+	tagHandler = tag2.NewHandler(tagService)
+
+	// Inquiry
+	inquiryRepo = inquiry2.NewRepo(db, rdb)
+	inquiryService = inquiry2.NewService(inquiryRepo, requireSecret("INQUIRY_SECRET"), inquiry2.Config{
+		MinFillTime: utils.GetDurationEnv("INQUIRY_MIN_FILL_TIME", 3*time.Second),
+		MaxFormAge:  utils.GetDurationEnv("INQUIRY_MAX_FORM_AGE", 24*time.Hour),
+		RateLimit:   int64(utils.GetIntEnv("INQUIRY_RATE_LIMIT", 5)),
+		RateWindow:  utils.GetDurationEnv("INQUIRY_RATE_WINDOW", time.Hour),
+	})
+	inquiryHandler = inquiry2.NewHandler(inquiryService)
+
+	// Testimonial
+	testimonialRepo = testimonial2.NewRepo(db, rdb)
+	testimonialService = testimonial2.NewService(testimonialRepo, testimonial2.Config{
+		RateLimit:  int64(utils.GetIntEnv("TESTIMONIAL_RATE_LIMIT", 5)),
+		RateWindow: utils.GetDurationEnv("TESTIMONIAL_RATE_WINDOW", time.Hour),
+	})
+	testimonialHandler = testimonial2.NewHandler(testimonialService)
+
+	// Archive
+	archiveRepo = archive2.NewRepo(db)
+	archiveService = archive2.NewService(archiveRepo, blobStore)
+	archiveHandler = archive2.NewHandler(archiveService)
+
+	// Feed
+	feedRepo = feed2.NewRepo(db)
+	feedService = feed2.NewService(feedRepo)
+	feedHandler = feed2.NewHandler(feedService)
+
+	memberRoute := utils.GetEnv("SITEMAP_MEMBER_ROUTE", "/members/{slug}")
+	projectRoute := utils.GetEnv("SITEMAP_PROJECT_ROUTE", "/projects/{slug}")
+
+	// Sitemap
+	sitemapRepo = sitemap2.NewRepo(db)
+	sitemapService = sitemap2.NewService(sitemapRepo, sitemap2.Config{
+		SiteURL:      utils.GetSiteURL(),
+		StaticRoutes: strings.Split(utils.GetEnv("SITEMAP_STATIC_ROUTES", "/,/members,/projects"), ","),
+		MemberRoute:  memberRoute,
+		ProjectRoute: projectRoute,
+		MaxURLs:      utils.GetIntEnv("SITEMAP_MAX_URLS", 50000),
+	})
+	sitemapHandler = sitemap2.NewHandler(sitemapService)
+
+	// Card
+	cardRepo = card2.NewRepo(db)
+	cardService = card2.NewService(cardRepo, blobStore, mediaService, utils.GetSiteName())
+	cardHandler = card2.NewHandler(cardService)
+
+	// Resume
+	resumeRepo = resume2.NewRepo(db)
+	resumeService = resume2.NewService(resumeRepo, translationService, mediaService, resume2.Config{
+		SiteName:     utils.GetSiteName(),
+		SiteURL:      utils.GetSiteURL(),
+		APIURL:       utils.GetAPIURL(),
+		MemberRoute:  memberRoute,
+		ProjectRoute: projectRoute,
+	})
+	resumeHandler = resume2.NewHandler(resumeService)
+
+	// Analytics
+	analyticsRepo = analytics2.NewRepo(db, rdb)
+	analyticsService = analytics2.NewService(analyticsRepo, analytics2.Config{
+		RateLimit:  int64(utils.GetIntEnv("ANALYTICS_RATE_LIMIT", 60)),
+		RateWindow: utils.GetDurationEnv("ANALYTICS_RATE_WINDOW", time.Minute),
+	})
+	analyticsHandler = analytics2.NewHandler(analyticsService)
+
+	// Reaction
+	reactionRepo = reaction2.NewRepo(db, rdb)
+	reactionService = reaction2.NewService(reactionRepo, requireSecret("REACTION_SECRET"), reaction2.Config{
+		RateLimit:    int64(utils.GetIntEnv("REACTION_RATE_LIMIT", 30)),
+		RateWindow:   utils.GetDurationEnv("REACTION_RATE_WINDOW", time.Minute),
+		SecureCookie: strings.HasPrefix(utils.GetAPIURL(), "https://"),
+	})
+	reactionHandler = reaction2.NewHandler(reactionService)
+
+	// Link
+	linkRepo = link2.NewRepo(db)
+	linkService = link2.NewService(linkRepo, link2.Config{
+		RecheckAfter: utils.GetDurationEnv("LINK_RECHECK_AFTER", 24*time.Hour),
+		Timeout:      utils.GetDurationEnv("LINK_CHECK_TIMEOUT", 10*time.Second),
+		Concurrency:  utils.GetIntEnv("LINK_CHECK_CONCURRENCY", 4),
+		BatchSize:    utils.GetIntEnv("LINK_CHECK_BATCH_SIZE", 50),
+		BrokenAfter:  utils.GetIntEnv("LINK_BROKEN_AFTER", 3),
+		UserAgent:    utils.GetSiteName() + " link checker (+" + utils.GetSiteURL() + ")",
+	})
+	linkHandler = link2.NewHandler(linkService)
+
+}
+
+func (s Server) initRoutes() {
+	// Rate limits key on the client IP, which gin would otherwise take from X-Forwarded-For of any request
+	err := s.Router.SetTrustedProxies(utils.GetTrustedProxies())
+	if err != nil {
+		logrus.Fatal("error setting trusted proxies: ", err)
+	}
+
+	r := NewRoutes(
+		s.Router,
+		userHandler,
+		jwtHandler,
+		memberHandler,
+		projectHandler,
+		searchHandler,
+		skillHandler,
+		roleHandler,
+		photoHandler,
+		trashHandler,
+		tagHandler,
+		inquiryHandler,
+		testimonialHandler,
+		storageHandler,
+		translationHandler,
+		revisionHandler,
+		archiveHandler,
+		feedHandler,
+		sitemapHandler,
+		cardHandler,
+		resumeHandler,
+		analyticsHandler,
+		reactionHandler,
+		linkHandler,
+	)
 	r.Init()
 }
 
 func (s Server) RunServer(port string) {
 	s.Router.Run(":" + port)
 }
+
+// requireSecret reads a signing secret from the environment, refusing to start without one
+// since anyone could forge what an empty secret signs
+func requireSecret(key string) string {
+	secret := os.Getenv(key)
+	if secret == "" {
+		logrus.Fatal(key, " is not set")
+	}
+
+	return secret
+}
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS project_members(
+    id serial PRIMARY KEY,
+    project_id INTEGER NOT NULL REFERENCES projects(id),
+    jastip_member_id INTEGER NOT NULL REFERENCES jastip_members(id),
+    role VARCHAR (128) NOT NULL,
+    UNIQUE (project_id, jastip_member_id)
+);
\ No newline at end of file
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS skills_search_vector_idx;
+ALTER TABLE skills DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS jastip_members_search_vector_idx;
+ALTER TABLE jastip_members DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS projects_search_vector_idx;
+ALTER TABLE projects DROP COLUMN IF EXISTS search_vector;
\ No newline at end of file
@@ -0,0 +1,17 @@
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(client_name, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS projects_search_vector_idx ON projects USING GIN (search_vector);
+
+ALTER TABLE jastip_members ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A')
+) STORED;
+CREATE INDEX IF NOT EXISTS jastip_members_search_vector_idx ON jastip_members USING GIN (search_vector);
+
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(skill, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS skills_search_vector_idx ON skills USING GIN (search_vector);
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS slug_history;
+
+ALTER TABLE jastip_members DROP COLUMN IF EXISTS slug;
+
+ALTER TABLE projects DROP COLUMN IF EXISTS slug;
\ No newline at end of file
@@ -0,0 +1,17 @@
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS slug VARCHAR (160);
+UPDATE projects SET slug = concat_ws('-', nullif(trim(both '-' from regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g')), ''), id) WHERE slug IS NULL;
+ALTER TABLE projects ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE projects ADD CONSTRAINT projects_slug_key UNIQUE (slug);
+
+ALTER TABLE jastip_members ADD COLUMN IF NOT EXISTS slug VARCHAR (160);
+UPDATE jastip_members SET slug = concat_ws('-', nullif(trim(both '-' from regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g')), ''), id) WHERE slug IS NULL;
+ALTER TABLE jastip_members ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE jastip_members ADD CONSTRAINT jastip_members_slug_key UNIQUE (slug);
+
+CREATE TABLE IF NOT EXISTS slug_history(
+    entity_type VARCHAR (32) NOT NULL,
+    slug VARCHAR (160) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (entity_type, slug)
+);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS projects_scheduled_idx;
+ALTER TABLE projects DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS status;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS status VARCHAR (16) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'published', 'archived'));
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ;
+
+-- Every existing project was already live
+UPDATE projects SET status = 'published', publish_at = NOW();
+
+CREATE INDEX IF NOT EXISTS projects_scheduled_idx ON projects (publish_at) WHERE status = 'draft' AND publish_at IS NOT NULL;
\ No newline at end of file
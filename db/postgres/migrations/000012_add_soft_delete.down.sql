@@ -0,0 +1,5 @@
+ALTER TABLE roles DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE skills DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE project_photos DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE jastip_members DROP COLUMN IF EXISTS deleted_at;
\ No newline at end of file
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS jastip_members_position_idx;
+ALTER TABLE jastip_members DROP COLUMN IF EXISTS featured;
+ALTER TABLE jastip_members DROP COLUMN IF EXISTS position;
+
+DROP INDEX IF EXISTS projects_position_idx;
+ALTER TABLE projects DROP COLUMN IF EXISTS featured;
+ALTER TABLE projects DROP COLUMN IF EXISTS position;
\ No newline at end of file
@@ -0,0 +1,13 @@
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS featured BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE projects SET position = ordered.position
+FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY id) AS position FROM projects) ordered
+WHERE projects.id = ordered.id;
+CREATE INDEX IF NOT EXISTS projects_position_idx ON projects (position, id);
+
+ALTER TABLE jastip_members ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE jastip_members ADD COLUMN IF NOT EXISTS featured BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE jastip_members SET position = ordered.position
+FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY id) AS position FROM jastip_members) ordered
+WHERE jastip_members.id = ordered.id;
+CREATE INDEX IF NOT EXISTS jastip_members_position_idx ON jastip_members (position, id);
\ No newline at end of file
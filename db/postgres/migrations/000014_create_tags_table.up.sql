@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS tags(
+    id serial PRIMARY KEY,
+    name VARCHAR (64) NOT NULL,
+    slug VARCHAR (80) UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS project_tags(
+    project_id INTEGER NOT NULL REFERENCES projects(id),
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (project_id, tag_id)
+);
+CREATE INDEX IF NOT EXISTS project_tags_tag_id_idx ON project_tags (tag_id);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS inquiry_notes;
+DROP TABLE IF EXISTS inquiries;
\ No newline at end of file
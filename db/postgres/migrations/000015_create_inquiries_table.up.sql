@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS inquiries(
+    id serial PRIMARY KEY,
+    name VARCHAR (128) NOT NULL,
+    email VARCHAR (254) NOT NULL,
+    company VARCHAR (128),
+    subject VARCHAR (200),
+    message TEXT NOT NULL,
+    status VARCHAR (16) NOT NULL DEFAULT 'new' CHECK (status IN ('new', 'read', 'archived')),
+    ip_address VARCHAR (64) NOT NULL,
+    user_agent TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS inquiries_status_idx ON inquiries (status, created_at);
+
+CREATE TABLE IF NOT EXISTS inquiry_notes(
+    id serial PRIMARY KEY,
+    inquiry_id INTEGER NOT NULL REFERENCES inquiries(id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users(id),
+    note TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS inquiry_notes_inquiry_id_idx ON inquiry_notes (inquiry_id);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS testimonials;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS testimonials(
+    id serial PRIMARY KEY,
+    project_id INTEGER NOT NULL REFERENCES projects(id),
+    quote TEXT NOT NULL,
+    author_name VARCHAR (128) NOT NULL,
+    author_title VARCHAR (128),
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    status VARCHAR (16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    moderated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS testimonials_project_id_idx ON testimonials (project_id);
\ No newline at end of file
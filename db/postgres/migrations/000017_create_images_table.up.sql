@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS images(
+    source TEXT PRIMARY KEY,
+    width INTEGER,
+    height INTEGER,
+    placeholder VARCHAR (64),
+    status VARCHAR (16) NOT NULL CHECK (status IN ('ready', 'failed')),
+    error TEXT,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS image_variants(
+    source TEXT NOT NULL REFERENCES images(source) ON DELETE CASCADE,
+    name VARCHAR (16) NOT NULL,
+    format VARCHAR (8) NOT NULL,
+    url TEXT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    PRIMARY KEY (source, name, format)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS translations;
\ No newline at end of file
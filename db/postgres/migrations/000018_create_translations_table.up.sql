@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS translations(
+    entity_type VARCHAR (16) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    locale VARCHAR (16) NOT NULL,
+    field VARCHAR (32) NOT NULL,
+    value TEXT NOT NULL,
+    PRIMARY KEY (entity_type, entity_id, locale, field)
+);
\ No newline at end of file
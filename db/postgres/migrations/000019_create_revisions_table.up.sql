@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS revisions(
+    id serial PRIMARY KEY,
+    entity_type VARCHAR (16) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    editor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    changed_fields TEXT[] NOT NULL,
+    snapshot JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS revisions_entity_idx ON revisions (entity_type, entity_id, id);
\ No newline at end of file
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS skill_endorsements;
+DROP INDEX IF EXISTS skills_rank_idx;
+ALTER TABLE skills DROP COLUMN IF EXISTS rank;
+ALTER TABLE skills DROP COLUMN IF EXISTS endorsement_count;
+ALTER TABLE skills DROP COLUMN IF EXISTS years_of_experience;
+ALTER TABLE skills DROP COLUMN IF EXISTS proficiency;
\ No newline at end of file
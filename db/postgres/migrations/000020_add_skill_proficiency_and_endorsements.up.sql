@@ -0,0 +1,14 @@
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS proficiency SMALLINT NOT NULL DEFAULT 0 CHECK (proficiency BETWEEN 0 AND 5);
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS years_of_experience SMALLINT NOT NULL DEFAULT 0 CHECK (years_of_experience BETWEEN 0 AND 80);
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS endorsement_count INTEGER NOT NULL DEFAULT 0;
+
+-- Skills are ranked by proficiency first, endorsements break ties. A single column keeps keyset pagination working.
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS rank INTEGER GENERATED ALWAYS AS (proficiency * 1000000 + LEAST(endorsement_count, 999999)) STORED;
+CREATE INDEX IF NOT EXISTS skills_rank_idx ON skills (rank, id);
+
+CREATE TABLE IF NOT EXISTS skill_endorsements(
+    skill_id INTEGER NOT NULL REFERENCES skills(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (skill_id, user_id)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS daily_views;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS daily_views(
+    entity_type VARCHAR (32) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    day DATE NOT NULL,
+    views INTEGER NOT NULL DEFAULT 0,
+    visitors INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (entity_type, entity_id, day)
+);
+CREATE INDEX IF NOT EXISTS daily_views_day_idx ON daily_views (entity_type, day);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS project_reactions;
\ No newline at end of file
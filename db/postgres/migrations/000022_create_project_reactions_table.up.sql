@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS project_reactions(
+    project_id INTEGER NOT NULL REFERENCES projects(id),
+    kind VARCHAR (16) NOT NULL,
+    count INTEGER NOT NULL DEFAULT 0 CHECK (count >= 0),
+    PRIMARY KEY (project_id, kind)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS project_links;
\ No newline at end of file
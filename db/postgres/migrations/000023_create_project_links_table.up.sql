@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS project_links(
+    id serial PRIMARY KEY,
+    project_id INTEGER NOT NULL REFERENCES projects(id),
+    type VARCHAR (16) NOT NULL,
+    label VARCHAR (128),
+    url VARCHAR (2048) NOT NULL,
+    health VARCHAR (16) NOT NULL DEFAULT 'unchecked',
+    status_code INTEGER,
+    final_url VARCHAR (2048),
+    redirects JSONB NOT NULL DEFAULT '[]',
+    check_error TEXT,
+    failures INTEGER NOT NULL DEFAULT 0,
+    checked_at TIMESTAMPTZ,
+    broken_since TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (project_id, url)
+);
+CREATE INDEX IF NOT EXISTS project_links_checked_at_idx ON project_links (checked_at NULLS FIRST);
\ No newline at end of file
@@ -0,0 +1,104 @@
+package analytics
+
+import "time"
+
+// Entity types whose pages are tracked
+const (
+	TypeProject = "project"
+	TypeMember  = "member"
+)
+
+const (
+	dayLayout = "2006-01-02"
+
+	// keyTTL is how long a day of views stays in Redis, long enough for a flush to pick up the last of it
+	keyTTL = 72 * time.Hour
+	// saltTTL is how long a day's visitor salt lives, once it's gone visitor hashes can't be recomputed
+	saltTTL = 48 * time.Hour
+
+	keyPrefix = "analytics:"
+
+	defaultRange = 30
+	maxRange     = 366
+	defaultLimit = 10
+	maxLimit     = 100
+)
+
+// View is a visit to a project or member page
+type View struct {
+	EntityType string
+	IDOrSlug   string
+	IPAddress  string
+	UserAgent  string
+}
+
+// DailyViews entity represent daily_views table in database, the views of an entity on one day
+type DailyViews struct {
+	EntityType string `db:"entity_type"`
+	EntityID   int    `db:"entity_id"`
+	Day        string `db:"day"`
+	Views      int64  `db:"views"`
+	Visitors   int64  `db:"visitors"`
+}
+
+// Point is one day of a time series. Visitors are unique within the day.
+type Point struct {
+	Day      string `json:"day" db:"day"`
+	Views    int64  `json:"views" db:"views"`
+	Visitors int64  `json:"visitors" db:"visitors"`
+}
+
+// Series is the views of every entity of a type, or of one entity, day by day
+type Series struct {
+	EntityType string  `json:"entity_type"`
+	EntityID   *int    `json:"entity_id,omitempty"`
+	From       string  `json:"from"`
+	To         string  `json:"to"`
+	Points     []Point `json:"points"`
+}
+
+// TopEntity is an entity ranked by views. Visitors add up each day's unique visitors,
+// someone coming back on another day is counted again.
+type TopEntity struct {
+	ID       int    `json:"id" db:"id"`
+	Name     string `json:"name" db:"name"`
+	Slug     string `json:"slug" db:"slug"`
+	Views    int64  `json:"views" db:"views"`
+	Visitors int64  `json:"visitors" db:"visitors"`
+}
+
+type Top struct {
+	EntityType string      `json:"entity_type"`
+	From       string      `json:"from"`
+	To         string      `json:"to"`
+	Entities   []TopEntity `json:"entities"`
+}
+
+// Range is the days, both included, analytics are read for
+type Range struct {
+	From time.Time
+	To   time.Time
+}
+
+// RecordViewAPIResponse API response for RecordView
+type RecordViewAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// SeriesAPIResponse API response for Series
+type SeriesAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Series  `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// TopAPIResponse API response for Top
+type TopAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Top     `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
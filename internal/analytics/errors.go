@@ -0,0 +1,9 @@
+package analytics
+
+import "github.com/pkg/errors"
+
+var (
+	ErrEntityNotFound  = errors.New("page not found")
+	ErrTooManyRequests = errors.New("too many views, please try again later")
+	ErrInternalServer  = errors.New("internal server error")
+)
@@ -0,0 +1,190 @@
+package analytics
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// RecordView counts a view of the page of a project or member, by id or slug
+func (h *Handler) RecordView(entityType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		view := View{
+			EntityType: entityType,
+			IDOrSlug:   c.Param("id"),
+			IPAddress:  c.ClientIP(),
+			UserAgent:  c.Request.UserAgent(),
+		}
+
+		err := h.service.Record(view, c)
+		if err != nil {
+			switch errors.Cause(err) {
+			case ErrEntityNotFound:
+				c.JSON(http.StatusNotFound, &RecordViewAPIResponse{
+					Status:  http.StatusNotFound,
+					Message: "not found",
+					Errors:  []string{ErrEntityNotFound.Error()},
+				})
+			case ErrTooManyRequests:
+				c.JSON(http.StatusTooManyRequests, &RecordViewAPIResponse{
+					Status:  http.StatusTooManyRequests,
+					Message: "too many requests",
+					Errors:  []string{ErrTooManyRequests.Error()},
+				})
+			default:
+				logrus.Error("[error while using record view service] ", err)
+				c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, &RecordViewAPIResponse{
+			Status:  http.StatusOK,
+			Message: "success",
+		})
+	}
+}
+
+// GetSeries returns the views of projects or members day by day, of one of them when id is given
+func (h *Handler) GetSeries(c *gin.Context) {
+	var entityID *int
+
+	// Input Validation
+	entityType, errorList := parseType(c)
+
+	if id := c.Query("id"); id != "" {
+		ID, err := strconv.Atoi(id)
+		if err != nil {
+			errorList = append(errorList, "id should be a number")
+		}
+		entityID = &ID
+	}
+
+	rng, rangeErrors := parseRange(c)
+	errorList = append(errorList, rangeErrors...)
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &SeriesAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Series(entityType, entityID, rng)
+	if err != nil {
+		logrus.Error("[error while using analytics series service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &SeriesAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+// GetTop returns the most viewed projects or members
+func (h *Handler) GetTop(c *gin.Context) {
+	limit := defaultLimit
+
+	// Input Validation
+	entityType, errorList := parseType(c)
+
+	if l := c.Query("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 1 || n > maxLimit {
+			errorList = append(errorList, fmt.Sprintf("limit should be a number between 1 and %d", maxLimit))
+		}
+		limit = n
+	}
+
+	rng, rangeErrors := parseRange(c)
+	errorList = append(errorList, rangeErrors...)
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &TopAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Top(entityType, rng, limit)
+	if err != nil {
+		logrus.Error("[error while using analytics top service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &TopAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+// parseType reads which kind of page analytics are asked for, projects when it's not given
+func parseType(c *gin.Context) (string, []string) {
+	entityType := c.DefaultQuery("type", TypeProject)
+	if _, ok := entities[entityType]; !ok {
+		return entityType, []string{fmt.Sprintf("type should be %s or %s", TypeProject, TypeMember)}
+	}
+
+	return entityType, nil
+}
+
+// parseRange reads the from and to days, the last 30 days up to today when they're not given
+func parseRange(c *gin.Context) (Range, []string) {
+	var errorList []string
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	rng := Range{To: today}
+
+	if to := c.Query("to"); to != "" {
+		day, err := time.Parse(dayLayout, to)
+		if err != nil {
+			errorList = append(errorList, "to should be a date formatted as YYYY-MM-DD")
+		}
+		rng.To = day
+	}
+
+	rng.From = rng.To.AddDate(0, 0, -(defaultRange - 1))
+	if from := c.Query("from"); from != "" {
+		day, err := time.Parse(dayLayout, from)
+		if err != nil {
+			errorList = append(errorList, "from should be a date formatted as YYYY-MM-DD")
+		}
+		rng.From = day
+	}
+
+	if len(errorList) != 0 {
+		return rng, errorList
+	}
+
+	if rng.From.After(rng.To) {
+		errorList = append(errorList, "from should not be after to")
+	} else if rng.To.Sub(rng.From) >= maxRange*24*time.Hour {
+		errorList = append(errorList, fmt.Sprintf("from and to should cover at most %d days", maxRange))
+	}
+
+	return rng, errorList
+}
@@ -0,0 +1,240 @@
+package analytics
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/ratelimit"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// entity is where the pages of an entity type come from
+type entity struct {
+	table string
+	// public narrows the table down to pages that can be viewed
+	public string
+}
+
+var entities = map[string]entity{
+	TypeProject: {table: "projects", public: "deleted_at IS NULL AND status = 'published'"},
+	TypeMember:  {table: "jastip_members", public: "deleted_at IS NULL"},
+}
+
+// NewRepo Redis for counting views as they happen and PostgreSQL for keeping them
+func NewRepo(db *sqlx.DB, rdb *redis.Client) Repo {
+	return &repo{
+		db:  db,
+		rdb: rdb,
+	}
+}
+
+type Repo interface {
+	Resolve(entityType, idOrSlug string) (int, error)
+	CountRequest(ipAddress string, window time.Duration, ctx context.Context) (int64, error)
+	Salt(day string, ctx context.Context) (string, error)
+	Record(entityType string, entityID int, day, visitor string, ctx context.Context) error
+	Collect(day string, ctx context.Context) ([]DailyViews, error)
+	Save(rows []DailyViews) error
+	Series(entityType string, entityID *int, rng Range) ([]Point, error)
+	Top(entityType string, rng Range, limit int) ([]TopEntity, error)
+}
+
+type repo struct {
+	db  *sqlx.DB
+	rdb *redis.Client
+}
+
+// Resolve finds the id of a page that can be viewed, by id or slug
+func (r repo) Resolve(entityType, idOrSlug string) (int, error) {
+	e := entities[entityType]
+
+	var ID int
+	err := r.db.Get(&ID, "SELECT id FROM "+e.table+" WHERE (slug = $1 OR id::text = $1) AND "+e.public, idOrSlug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.Wrap(ErrEntityNotFound, err.Error())
+		}
+		return 0, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return ID, nil
+}
+
+// Salt returns the random salt visitors are hashed with on day. Whichever
+// instance asks first picks it, every other one gets the same salt.
+func (r repo) Salt(day string, ctx context.Context) (string, error) {
+	key := keyPrefix + "salt:" + day
+
+	var get *redis.StringCmd
+	_, err := r.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SetNX(ctx, key, newSalt(), saltTTL)
+		get = pipe.Get(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return "", errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return get.Val(), nil
+}
+
+// CountRequest counts a view recorded from ipAddress and returns how many were recorded in the current window
+func (r repo) CountRequest(ipAddress string, window time.Duration, ctx context.Context) (int64, error) {
+	count, err := ratelimit.Count(r.rdb, keyPrefix+"rate_limit:"+ipAddress, window, ctx)
+	if err != nil {
+		return 0, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return count, nil
+}
+
+// Record counts a view of a page and adds the visitor to the page's unique visitors of the day
+func (r repo) Record(entityType string, entityID int, day, visitor string, ctx context.Context) error {
+	page := entityType + ":" + strconv.Itoa(entityID)
+	viewsKey := keyPrefix + "views:" + day + ":" + page
+	visitorsKey := keyPrefix + "visitors:" + day + ":" + page
+	pagesKey := keyPrefix + "pages:" + day
+
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Incr(ctx, viewsKey)
+		pipe.PFAdd(ctx, visitorsKey, visitor)
+		pipe.SAdd(ctx, pagesKey, page)
+		pipe.Expire(ctx, viewsKey, keyTTL)
+		pipe.Expire(ctx, visitorsKey, keyTTL)
+		pipe.Expire(ctx, pagesKey, keyTTL)
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+// Collect reads the views and unique visitors of every page viewed on day so far
+func (r repo) Collect(day string, ctx context.Context) ([]DailyViews, error) {
+	pages, err := r.rdb.SMembers(ctx, keyPrefix+"pages:"+day).Result()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	if len(pages) == 0 {
+		return nil, nil
+	}
+
+	views := make([]*redis.StringCmd, len(pages))
+	visitors := make([]*redis.IntCmd, len(pages))
+	_, err = r.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, page := range pages {
+			views[i] = pipe.Get(ctx, keyPrefix+"views:"+day+":"+page)
+			visitors[i] = pipe.PFCount(ctx, keyPrefix+"visitors:"+day+":"+page)
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	rows := make([]DailyViews, 0, len(pages))
+	for i, page := range pages {
+		parts := strings.SplitN(page, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		entityID, err := strconv.Atoi(parts[1])
+		if err != nil {
+			continue
+		}
+		count, err := views[i].Int64()
+		if err != nil {
+			continue
+		}
+
+		rows = append(rows, DailyViews{
+			EntityType: parts[0],
+			EntityID:   entityID,
+			Day:        day,
+			Views:      count,
+			Visitors:   visitors[i].Val(),
+		})
+	}
+
+	return rows, nil
+}
+
+// Save stores the totals of each page on its day. Redis holds the running totals
+// of a day, so saving the same day again replaces what an earlier flush saved.
+func (r repo) Save(rows []DailyViews) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	for _, row := range rows {
+		_, err = tx.NamedExec(`
+			INSERT INTO daily_views (entity_type, entity_id, day, views, visitors)
+			VALUES (:entity_type, :entity_id, :day, :views, :visitors)
+			ON CONFLICT (entity_type, entity_id, day) DO UPDATE SET views = EXCLUDED.views, visitors = EXCLUDED.visitors`, row)
+		if err != nil {
+			return errors.Wrap(ErrInternalServer, err.Error())
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+// Series adds up the views of every page of entityType, or just entityID's, for each day in rng. Days without views are zero.
+func (r repo) Series(entityType string, entityID *int, rng Range) ([]Point, error) {
+	points := []Point{}
+	err := r.db.Select(&points, `
+		SELECT to_char(d.day, 'YYYY-MM-DD') AS day, COALESCE(SUM(v.views), 0) AS views, COALESCE(SUM(v.visitors), 0) AS visitors
+		FROM generate_series($1::date, $2::date, interval '1 day') AS d(day)
+		LEFT JOIN daily_views v ON v.day = d.day AND v.entity_type = $3 AND ($4::integer IS NULL OR v.entity_id = $4)
+		GROUP BY d.day
+		ORDER BY d.day`, rng.From.Format(dayLayout), rng.To.Format(dayLayout), entityType, entityID)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return points, nil
+}
+
+// Top ranks the pages of entityType by their views in rng, leaving out anything in the trash
+func (r repo) Top(entityType string, rng Range, limit int) ([]TopEntity, error) {
+	e := entities[entityType]
+
+	top := []TopEntity{}
+	err := r.db.Select(&top, `
+		SELECT e.id, e.name, e.slug, SUM(v.views) AS views, SUM(v.visitors) AS visitors FROM daily_views v
+		JOIN `+e.table+` e ON e.id = v.entity_id AND e.deleted_at IS NULL
+		WHERE v.entity_type = $1 AND v.day BETWEEN $2 AND $3
+		GROUP BY e.id, e.name, e.slug
+		ORDER BY views DESC, e.id
+		LIMIT $4`, entityType, rng.From.Format(dayLayout), rng.To.Format(dayLayout), limit)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return top, nil
+}
+
+func newSalt() string {
+	salt := make([]byte, 32)
+	_, _ = rand.Read(salt)
+	return hex.EncodeToString(salt)
+}
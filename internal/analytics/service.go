@@ -0,0 +1,137 @@
+package analytics
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+)
+
+// botMarkers are found in the user agent of crawlers, whose visits aren't views
+var botMarkers = []string{"bot", "crawl", "spider", "slurp", "preview", "headless"}
+
+// Config limits how many views a client can record
+type Config struct {
+	// RateLimit is how many views an IP address may record per RateWindow
+	RateLimit  int64
+	RateWindow time.Duration
+}
+
+func NewService(repo Repo, config Config) Service {
+	return &service{
+		repo:   repo,
+		config: config,
+	}
+}
+
+type Service interface {
+	Record(view View, ctx context.Context) error
+	Flush(ctx context.Context) (int, error)
+	Series(entityType string, entityID *int, rng Range) (*Series, error)
+	Top(entityType string, rng Range, limit int) (*Top, error)
+}
+
+type service struct {
+	repo   Repo
+	config Config
+}
+
+// Record counts a page view. Visitors are only known by a hash of their IP address and
+// user agent salted with a secret of the day, so they can't be told apart from one day to
+// the next and nothing identifying them is stored.
+func (s service) Record(view View, ctx context.Context) error {
+	count, err := s.repo.CountRequest(view.IPAddress, s.config.RateWindow, ctx)
+	if err != nil {
+		return err
+	}
+
+	if count > s.config.RateLimit {
+		return errors.Wrapf(ErrTooManyRequests, "%d views from %s", count, view.IPAddress)
+	}
+
+	entityID, err := s.repo.Resolve(view.EntityType, view.IDOrSlug)
+	if err != nil {
+		return err
+	}
+
+	if isBot(view.UserAgent) {
+		return nil
+	}
+
+	day := time.Now().UTC().Format(dayLayout)
+	salt, err := s.repo.Salt(day, ctx)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256([]byte(salt + "|" + view.IPAddress + "|" + view.UserAgent))
+
+	return s.repo.Record(view.EntityType, entityID, day, hex.EncodeToString(sum[:16]), ctx)
+}
+
+// Flush copies the views counted in Redis to PostgreSQL for every day Redis still holds,
+// and returns how many daily rows were saved
+func (s service) Flush(ctx context.Context) (int, error) {
+	saved := 0
+	now := time.Now().UTC()
+	for age := time.Duration(0); age < keyTTL; age += 24 * time.Hour {
+		rows, err := s.repo.Collect(now.Add(-age).Format(dayLayout), ctx)
+		if err != nil {
+			return saved, err
+		}
+
+		err = s.repo.Save(rows)
+		if err != nil {
+			return saved, err
+		}
+		saved += len(rows)
+	}
+
+	return saved, nil
+}
+
+func (s service) Series(entityType string, entityID *int, rng Range) (*Series, error) {
+	points, err := s.repo.Series(entityType, entityID, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Series{
+		EntityType: entityType,
+		EntityID:   entityID,
+		From:       rng.From.Format(dayLayout),
+		To:         rng.To.Format(dayLayout),
+		Points:     points,
+	}, nil
+}
+
+func (s service) Top(entityType string, rng Range, limit int) (*Top, error) {
+	entities, err := s.repo.Top(entityType, rng, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Top{
+		EntityType: entityType,
+		From:       rng.From.Format(dayLayout),
+		To:         rng.To.Format(dayLayout),
+		Entities:   entities,
+	}, nil
+}
+
+func isBot(userAgent string) bool {
+	if userAgent == "" {
+		return true
+	}
+
+	userAgent = strings.ToLower(userAgent)
+	for _, marker := range botMarkers {
+		if strings.Contains(userAgent, marker) {
+			return true
+		}
+	}
+
+	return false
+}
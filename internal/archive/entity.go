@@ -0,0 +1,153 @@
+package archive
+
+import "time"
+
+// Version of the archive format, bump it when the layout of Archive changes
+const Version = 1
+
+// Archive formats, the manifest inside the zip is portfolio.json or portfolio.yaml
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Import modes. Merge updates rows matching by their natural key and adds the rest,
+// replace wipes the portfolio before importing.
+const (
+	ModeMerge   = "merge"
+	ModeReplace = "replace"
+)
+
+const (
+	manifestName = "portfolio"
+	filesDir     = "files/"
+)
+
+// Archive is the whole portfolio. IDs are only meaningful inside the archive,
+// they're remapped to new database IDs on import.
+type Archive struct {
+	Version    int       `json:"version" yaml:"version"`
+	ExportedAt time.Time `json:"exported_at" yaml:"exported_at"`
+	Members    []Member  `json:"members" yaml:"members"`
+	Roles      []Role    `json:"roles" yaml:"roles"`
+	Skills     []Skill   `json:"skills" yaml:"skills"`
+	Projects   []Project `json:"projects" yaml:"projects"`
+	Photos     []Photo   `json:"photos" yaml:"photos"`
+}
+
+// Member is a jastip member with the roles it holds.
+// Uploaded photos are bundled in the archive and referenced by PhotoFile instead of Photo.
+type Member struct {
+	ID        int     `json:"id" yaml:"id" db:"id"`
+	Name      string  `json:"name" yaml:"name" db:"name"`
+	Slug      string  `json:"slug" yaml:"slug" db:"slug"`
+	Photo     *string `json:"photo,omitempty" yaml:"photo,omitempty" db:"photo"`
+	PhotoFile *string `json:"photo_file,omitempty" yaml:"photo_file,omitempty" db:"-"`
+	Position  int     `json:"position" yaml:"position" db:"position"`
+	Featured  bool    `json:"featured" yaml:"featured" db:"featured"`
+	RoleIDs   []int   `json:"role_ids" yaml:"role_ids" db:"-"`
+}
+
+type Role struct {
+	ID          int     `json:"id" yaml:"id" db:"id"`
+	Name        string  `json:"name" yaml:"name" db:"name"`
+	Description *string `json:"description,omitempty" yaml:"description,omitempty" db:"description"`
+}
+
+type Skill struct {
+	ID          int     `json:"id" yaml:"id" db:"id"`
+	Skill       string  `json:"skill" yaml:"skill" db:"skill"`
+	Description *string `json:"description,omitempty" yaml:"description,omitempty" db:"description"`
+	MemberID    *int    `json:"member_id,omitempty" yaml:"member_id,omitempty" db:"jastip_member_id"`
+	// Proficiency and YearsOfExperience were added to version 1 later on, archives without them import as unrated
+	Proficiency       int `json:"proficiency" yaml:"proficiency" db:"proficiency"`
+	YearsOfExperience int `json:"years_of_experience" yaml:"years_of_experience" db:"years_of_experience"`
+	// Endorsements are the usernames of the users who endorsed the skill. Users aren't part of the archive,
+	// endorsements by a username the importing server doesn't have are dropped.
+	Endorsements []string `json:"endorsements" yaml:"endorsements" db:"-"`
+}
+
+// Project carries its tags by name, its team by archive member ID and its external links
+type Project struct {
+	ID          int          `json:"id" yaml:"id" db:"id"`
+	Name        string       `json:"name" yaml:"name" db:"name"`
+	Slug        string       `json:"slug" yaml:"slug" db:"slug"`
+	ClientName  string       `json:"client_name" yaml:"client_name" db:"client_name"`
+	Description *string      `json:"description,omitempty" yaml:"description,omitempty" db:"description"`
+	Status      string       `json:"status" yaml:"status" db:"status"`
+	PublishAt   *time.Time   `json:"publish_at,omitempty" yaml:"publish_at,omitempty" db:"publish_at"`
+	Position    int          `json:"position" yaml:"position" db:"position"`
+	Featured    bool         `json:"featured" yaml:"featured" db:"featured"`
+	Tags        []string     `json:"tags" yaml:"tags" db:"-"`
+	Team        []TeamMember `json:"team" yaml:"team" db:"-"`
+	// Links were added to version 1 later on, archives without them import with no links
+	Links []Link `json:"links" yaml:"links" db:"-"`
+	// Testimonials and Reactions came after Links, archives without them import with neither
+	Testimonials []Testimonial  `json:"testimonials" yaml:"testimonials" db:"-"`
+	Reactions    map[string]int `json:"reactions" yaml:"reactions" db:"-"`
+}
+
+type TeamMember struct {
+	MemberID int    `json:"member_id" yaml:"member_id" db:"jastip_member_id"`
+	Role     string `json:"role" yaml:"role" db:"role"`
+}
+
+// Link leaves out the health of a link, the link checker finds it again after an import
+type Link struct {
+	Type  string  `json:"type" yaml:"type" db:"type"`
+	Label *string `json:"label,omitempty" yaml:"label,omitempty" db:"label"`
+	URL   string  `json:"url" yaml:"url" db:"url"`
+}
+
+// Testimonial keeps its moderation status, pending testimonials still need moderating after an import
+type Testimonial struct {
+	Quote       string     `json:"quote" yaml:"quote" db:"quote"`
+	AuthorName  string     `json:"author_name" yaml:"author_name" db:"author_name"`
+	AuthorTitle *string    `json:"author_title,omitempty" yaml:"author_title,omitempty" db:"author_title"`
+	Rating      int        `json:"rating" yaml:"rating" db:"rating"`
+	Status      string     `json:"status" yaml:"status" db:"status"`
+	CreatedAt   time.Time  `json:"created_at" yaml:"created_at" db:"created_at"`
+	ModeratedAt *time.Time `json:"moderated_at,omitempty" yaml:"moderated_at,omitempty" db:"moderated_at"`
+}
+
+type Photo struct {
+	ID          int     `json:"id" yaml:"id" db:"id"`
+	ProjectID   *int    `json:"project_id,omitempty" yaml:"project_id,omitempty" db:"project_id"`
+	Photo       *string `json:"photo,omitempty" yaml:"photo,omitempty" db:"photo"`
+	PhotoFile   *string `json:"photo_file,omitempty" yaml:"photo_file,omitempty" db:"-"`
+	Description *string `json:"description,omitempty" yaml:"description,omitempty" db:"description"`
+}
+
+// Count of rows an import created and updated
+type Count struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+}
+
+// Result summarizes an import, a dry run reports what would have been imported
+type Result struct {
+	Mode         string `json:"mode"`
+	DryRun       bool   `json:"dry_run"`
+	Members      Count  `json:"members"`
+	Roles        Count  `json:"roles"`
+	Skills       Count  `json:"skills"`
+	Projects     Count  `json:"projects"`
+	Testimonials Count  `json:"testimonials"`
+	Photos       Count  `json:"photos"`
+	Files        int    `json:"files"`
+}
+
+// ImportAPIResponse API response for Import
+type ImportAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Result  `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// ExportAPIResponse API response for Export, only used when the export fails
+type ExportAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Errors  []string `json:"errors,omitempty"`
+}
@@ -0,0 +1,7 @@
+package archive
+
+import "github.com/pkg/errors"
+
+var (
+	ErrInternalServer = errors.New("internal server error")
+)
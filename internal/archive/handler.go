@@ -0,0 +1,118 @@
+package archive
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/storage"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// ExportArchive streams the portfolio as a zip download, ?format=yaml picks a YAML manifest over JSON
+func (h *Handler) ExportArchive(c *gin.Context) {
+	// Input Validation
+	format := c.DefaultQuery("format", FormatJSON)
+	if format != FormatJSON && format != FormatYAML {
+		c.JSON(http.StatusBadRequest, &ExportAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  []string{"format should be json or yaml"},
+		})
+		return
+	}
+
+	filename := "portfolio-" + time.Now().UTC().Format("20060102-150405") + ".zip"
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+	err := h.service.Export(c.Writer, format, c)
+	if err != nil {
+		logrus.Error("[error while using export archive service] ", err)
+		// Once the zip started streaming the response can't be turned into an error anymore
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		}
+		return
+	}
+}
+
+// ImportArchive loads an exported archive. The mode form field is merge, the default, or replace
+// and dry_run=true validates and reports what would change without saving anything.
+func (h *Handler) ImportArchive(c *gin.Context) {
+	var errorList []string
+
+	// Input Validation
+	data, err := storage.ReadUpload(c, "archive", utils.GetMaxImportSize())
+	if err != nil {
+		switch errors.Cause(err) {
+		case storage.ErrFileTooLarge:
+			c.JSON(http.StatusRequestEntityTooLarge, &ImportAPIResponse{
+				Status:  http.StatusRequestEntityTooLarge,
+				Message: "request entity too large",
+				Errors:  []string{storage.ErrFileTooLarge.Error()},
+			})
+			return
+		case storage.ErrMissingFile:
+			errorList = append(errorList, "archive is required")
+		default:
+			logrus.Error("[error while reading uploaded archive] ", err)
+			c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+			return
+		}
+	}
+
+	mode := c.DefaultPostForm("mode", ModeMerge)
+	if mode != ModeMerge && mode != ModeReplace {
+		errorList = append(errorList, "mode should be merge or replace")
+	}
+
+	dryRun, err := strconv.ParseBool(c.DefaultPostForm("dry_run", "false"))
+	if err != nil {
+		errorList = append(errorList, "dry_run should be a boolean")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ImportAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, errorList, err := h.service.Import(data, mode, dryRun, c)
+	if err != nil {
+		logrus.Error("[error while using import archive service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ImportAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &ImportAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
@@ -0,0 +1,535 @@
+package archive
+
+import (
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/slugs"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+)
+
+// entityTypes are the entity types kept in slug_history, translations, revisions and daily_views which a replace import wipes along with the rows
+var entityTypes = []string{"member", "project", "skill", "role"}
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+type Repo interface {
+	Export() (*Archive, error)
+	Import(archive *Archive, mode string, dryRun bool) (*Result, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+// Export reads the portfolio as a consistent snapshot, leaving out whatever is in the trash
+func (r repo) Export() (*Archive, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ READ ONLY")
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	archive := Archive{
+		Members:  []Member{},
+		Roles:    []Role{},
+		Skills:   []Skill{},
+		Projects: []Project{},
+		Photos:   []Photo{},
+	}
+
+	err = tx.Select(&archive.Members, "SELECT id, name, slug, photo, position, featured FROM jastip_members WHERE deleted_at IS NULL ORDER BY id")
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = tx.Select(&archive.Roles, "SELECT id, name, description FROM roles WHERE deleted_at IS NULL ORDER BY id")
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	var memberRoles []struct {
+		MemberID int `db:"jastip_member_id"`
+		RoleID   int `db:"role_id"`
+	}
+	err = tx.Select(&memberRoles, `
+		SELECT DISTINCT mr.jastip_member_id, mr.role_id FROM jastip_member_roles mr
+		JOIN jastip_members m ON m.id = mr.jastip_member_id AND m.deleted_at IS NULL
+		JOIN roles r ON r.id = mr.role_id AND r.deleted_at IS NULL
+		ORDER BY mr.jastip_member_id, mr.role_id`)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	members := make(map[int]*Member, len(archive.Members))
+	for i := range archive.Members {
+		archive.Members[i].RoleIDs = []int{}
+		members[archive.Members[i].ID] = &archive.Members[i]
+	}
+	for _, mr := range memberRoles {
+		members[mr.MemberID].RoleIDs = append(members[mr.MemberID].RoleIDs, mr.RoleID)
+	}
+
+	err = tx.Select(&archive.Skills, `
+		SELECT s.id, s.skill, s.description, s.jastip_member_id, s.proficiency, s.years_of_experience FROM skills s
+		LEFT JOIN jastip_members m ON m.id = s.jastip_member_id
+		WHERE s.deleted_at IS NULL AND m.deleted_at IS NULL
+		ORDER BY s.id`)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	skills := make(map[int]*Skill, len(archive.Skills))
+	for i := range archive.Skills {
+		archive.Skills[i].Endorsements = []string{}
+		skills[archive.Skills[i].ID] = &archive.Skills[i]
+	}
+
+	var endorsements []struct {
+		SkillID  int    `db:"skill_id"`
+		Username string `db:"username"`
+	}
+	err = tx.Select(&endorsements, `
+		SELECT e.skill_id, u.username FROM skill_endorsements e
+		JOIN users u ON u.id = e.user_id
+		JOIN skills s ON s.id = e.skill_id AND s.deleted_at IS NULL
+		LEFT JOIN jastip_members m ON m.id = s.jastip_member_id
+		WHERE m.deleted_at IS NULL
+		ORDER BY e.skill_id, u.username`)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	for _, e := range endorsements {
+		skills[e.SkillID].Endorsements = append(skills[e.SkillID].Endorsements, e.Username)
+	}
+
+	err = tx.Select(&archive.Projects, `
+		SELECT id, name, slug, client_name, description, status, publish_at, position, featured FROM projects
+		WHERE deleted_at IS NULL ORDER BY id`)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	projects := make(map[int]*Project, len(archive.Projects))
+	for i := range archive.Projects {
+		archive.Projects[i].Tags = []string{}
+		archive.Projects[i].Team = []TeamMember{}
+		archive.Projects[i].Links = []Link{}
+		archive.Projects[i].Testimonials = []Testimonial{}
+		archive.Projects[i].Reactions = map[string]int{}
+		projects[archive.Projects[i].ID] = &archive.Projects[i]
+	}
+
+	var projectTags []struct {
+		ProjectID int    `db:"project_id"`
+		Name      string `db:"name"`
+	}
+	err = tx.Select(&projectTags, `
+		SELECT pt.project_id, t.name FROM project_tags pt
+		JOIN tags t ON t.id = pt.tag_id
+		JOIN projects p ON p.id = pt.project_id AND p.deleted_at IS NULL
+		ORDER BY pt.project_id, t.name`)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	for _, pt := range projectTags {
+		projects[pt.ProjectID].Tags = append(projects[pt.ProjectID].Tags, pt.Name)
+	}
+
+	var team []struct {
+		ProjectID int `db:"project_id"`
+		TeamMember
+	}
+	err = tx.Select(&team, `
+		SELECT pm.project_id, pm.jastip_member_id, pm.role FROM project_members pm
+		JOIN projects p ON p.id = pm.project_id AND p.deleted_at IS NULL
+		JOIN jastip_members m ON m.id = pm.jastip_member_id AND m.deleted_at IS NULL
+		ORDER BY pm.project_id, pm.id`)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	for _, t := range team {
+		projects[t.ProjectID].Team = append(projects[t.ProjectID].Team, t.TeamMember)
+	}
+
+	var links []struct {
+		ProjectID int `db:"project_id"`
+		Link
+	}
+	err = tx.Select(&links, `
+		SELECT l.project_id, l.type, l.label, l.url FROM project_links l
+		JOIN projects p ON p.id = l.project_id AND p.deleted_at IS NULL
+		ORDER BY l.project_id, l.id`)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	for _, l := range links {
+		projects[l.ProjectID].Links = append(projects[l.ProjectID].Links, l.Link)
+	}
+
+	var testimonials []struct {
+		ProjectID int `db:"project_id"`
+		Testimonial
+	}
+	err = tx.Select(&testimonials, `
+		SELECT t.project_id, t.quote, t.author_name, t.author_title, t.rating, t.status, t.created_at, t.moderated_at FROM testimonials t
+		JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+		ORDER BY t.project_id, t.id`)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	for _, t := range testimonials {
+		projects[t.ProjectID].Testimonials = append(projects[t.ProjectID].Testimonials, t.Testimonial)
+	}
+
+	// Only the saved counts are exported, reactions still waiting in Redis aren't part of the snapshot
+	var reactions []struct {
+		ProjectID int    `db:"project_id"`
+		Kind      string `db:"kind"`
+		Count     int    `db:"count"`
+	}
+	err = tx.Select(&reactions, `
+		SELECT r.project_id, r.kind, r.count FROM project_reactions r
+		JOIN projects p ON p.id = r.project_id AND p.deleted_at IS NULL
+		WHERE r.count > 0`)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	for _, r := range reactions {
+		projects[r.ProjectID].Reactions[r.Kind] = r.Count
+	}
+
+	err = tx.Select(&archive.Photos, `
+		SELECT ph.id, ph.project_id, ph.photo, ph.description FROM project_photos ph
+		LEFT JOIN projects p ON p.id = ph.project_id
+		WHERE ph.deleted_at IS NULL AND p.deleted_at IS NULL
+		ORDER BY ph.id`)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &archive, nil
+}
+
+// Import writes a validated archive in a single transaction, so a failure halfway leaves the portfolio untouched.
+// Archive IDs are remapped to database IDs as rows are written. A dry run does all the work and then rolls back.
+func (r repo) Import(archive *Archive, mode string, dryRun bool) (*Result, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	result := Result{Mode: mode, DryRun: dryRun}
+	merge := mode == ModeMerge
+
+	if mode == ModeReplace {
+		err = wipe(tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	roleIDs := make(map[int]int, len(archive.Roles))
+	for _, role := range archive.Roles {
+		ID, found, err := findID(tx, merge, "SELECT id FROM roles WHERE name=$1 ORDER BY deleted_at NULLS FIRST, id LIMIT 1", role.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			_, err = tx.Exec("UPDATE roles SET description=$1, deleted_at=NULL WHERE id=$2", role.Description, ID)
+			result.Roles.Updated++
+		} else {
+			err = tx.Get(&ID, "INSERT INTO roles (name, description) VALUES ($1, $2) RETURNING id", role.Name, role.Description)
+			result.Roles.Created++
+		}
+		if err != nil {
+			return nil, errors.Wrap(ErrInternalServer, err.Error())
+		}
+		roleIDs[role.ID] = ID
+	}
+
+	memberIDs := make(map[int]int, len(archive.Members))
+	for _, member := range archive.Members {
+		// Slugs are unique even in the trash, so a trashed member with the same slug is brought back
+		ID, found, err := findID(tx, merge, "SELECT id FROM jastip_members WHERE slug=$1", member.Slug)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			_, err = tx.Exec(`
+				UPDATE jastip_members SET name=$1, photo=$2, position=$3, featured=$4, deleted_at=NULL WHERE id=$5`,
+				member.Name, member.Photo, member.Position, member.Featured, ID)
+			result.Members.Updated++
+		} else {
+			err = tx.Get(&ID, `
+				INSERT INTO jastip_members (name, slug, photo, position, featured) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+				member.Name, member.Slug, member.Photo, member.Position, member.Featured)
+			result.Members.Created++
+		}
+		if err != nil {
+			return nil, errors.Wrap(ErrInternalServer, err.Error())
+		}
+		memberIDs[member.ID] = ID
+
+		err = slugs.Members.Release(tx, member.Slug)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, roleID := range member.RoleIDs {
+			_, err = tx.Exec(`
+				INSERT INTO jastip_member_roles (jastip_member_id, role_id)
+				SELECT $1, $2 WHERE NOT EXISTS (SELECT 1 FROM jastip_member_roles WHERE jastip_member_id=$1 AND role_id=$2)`,
+				ID, roleIDs[roleID])
+			if err != nil {
+				return nil, errors.Wrap(ErrInternalServer, err.Error())
+			}
+		}
+	}
+
+	for _, skill := range archive.Skills {
+		var memberID *int
+		if skill.MemberID != nil {
+			ID := memberIDs[*skill.MemberID]
+			memberID = &ID
+		}
+
+		ID, found, err := findID(tx, merge, `
+			SELECT id FROM skills WHERE skill=$1 AND jastip_member_id IS NOT DISTINCT FROM $2
+			ORDER BY deleted_at NULLS FIRST, id LIMIT 1`, skill.Skill, memberID)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			_, err = tx.Exec("UPDATE skills SET description=$1, proficiency=$2, years_of_experience=$3, deleted_at=NULL WHERE id=$4", skill.Description, skill.Proficiency, skill.YearsOfExperience, ID)
+			result.Skills.Updated++
+		} else {
+			err = tx.Get(&ID, `
+				INSERT INTO skills (skill, description, jastip_member_id, proficiency, years_of_experience) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+				skill.Skill, skill.Description, memberID, skill.Proficiency, skill.YearsOfExperience)
+			result.Skills.Created++
+		}
+		if err != nil {
+			return nil, errors.Wrap(ErrInternalServer, err.Error())
+		}
+
+		for _, username := range skill.Endorsements {
+			_, err = tx.Exec(`
+				INSERT INTO skill_endorsements (skill_id, user_id) SELECT $1, id FROM users WHERE username=$2
+				ON CONFLICT (skill_id, user_id) DO NOTHING`, ID, username)
+			if err != nil {
+				return nil, errors.Wrap(ErrInternalServer, err.Error())
+			}
+		}
+
+		_, err = tx.Exec("UPDATE skills SET endorsement_count=(SELECT COUNT(*) FROM skill_endorsements WHERE skill_id=$1) WHERE id=$1", ID)
+		if err != nil {
+			return nil, errors.Wrap(ErrInternalServer, err.Error())
+		}
+	}
+
+	projectIDs := make(map[int]int, len(archive.Projects))
+	for _, project := range archive.Projects {
+		ID, found, err := findID(tx, merge, "SELECT id FROM projects WHERE slug=$1", project.Slug)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			_, err = tx.Exec(`
+				UPDATE projects SET name=$1, client_name=$2, description=$3, status=$4, publish_at=$5, position=$6, featured=$7, deleted_at=NULL
+				WHERE id=$8`,
+				project.Name, project.ClientName, project.Description, project.Status, project.PublishAt, project.Position, project.Featured, ID)
+			result.Projects.Updated++
+		} else {
+			err = tx.Get(&ID, `
+				INSERT INTO projects (name, slug, client_name, description, status, publish_at, position, featured)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+				project.Name, project.Slug, project.ClientName, project.Description, project.Status, project.PublishAt, project.Position, project.Featured)
+			result.Projects.Created++
+		}
+		if err != nil {
+			return nil, errors.Wrap(ErrInternalServer, err.Error())
+		}
+		projectIDs[project.ID] = ID
+
+		err = slugs.Projects.Release(tx, project.Slug)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range project.Tags {
+			var tagID int
+			err = tx.Get(&tagID, `
+				INSERT INTO tags (name, slug) VALUES ($1, $2)
+				ON CONFLICT (slug) DO UPDATE SET name = tags.name
+				RETURNING id`, name, utils.Slugify(name))
+			if err != nil {
+				return nil, errors.Wrap(ErrInternalServer, err.Error())
+			}
+
+			_, err = tx.Exec("INSERT INTO project_tags (project_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", ID, tagID)
+			if err != nil {
+				return nil, errors.Wrap(ErrInternalServer, err.Error())
+			}
+		}
+
+		for _, member := range project.Team {
+			_, err = tx.Exec(`
+				INSERT INTO project_members (project_id, jastip_member_id, role) VALUES ($1, $2, $3)
+				ON CONFLICT (project_id, jastip_member_id) DO UPDATE SET role = EXCLUDED.role`,
+				ID, memberIDs[member.MemberID], member.Role)
+			if err != nil {
+				return nil, errors.Wrap(ErrInternalServer, err.Error())
+			}
+		}
+
+		for _, link := range project.Links {
+			_, err = tx.Exec(`
+				INSERT INTO project_links (project_id, type, label, url) VALUES ($1, $2, $3, $4)
+				ON CONFLICT (project_id, url) DO UPDATE SET type = EXCLUDED.type, label = EXCLUDED.label`,
+				ID, link.Type, link.Label, link.URL)
+			if err != nil {
+				return nil, errors.Wrap(ErrInternalServer, err.Error())
+			}
+		}
+
+		for _, t := range project.Testimonials {
+			testimonialID, found, err := findID(tx, merge, `
+				SELECT id FROM testimonials WHERE project_id=$1 AND author_name=$2 AND quote=$3 ORDER BY id LIMIT 1`,
+				ID, t.AuthorName, t.Quote)
+			if err != nil {
+				return nil, err
+			}
+
+			if found {
+				_, err = tx.Exec(`
+					UPDATE testimonials SET author_title=$1, rating=$2, status=$3, created_at=$4, moderated_at=$5 WHERE id=$6`,
+					t.AuthorTitle, t.Rating, t.Status, t.CreatedAt, t.ModeratedAt, testimonialID)
+				result.Testimonials.Updated++
+			} else {
+				_, err = tx.Exec(`
+					INSERT INTO testimonials (project_id, quote, author_name, author_title, rating, status, created_at, moderated_at)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+					ID, t.Quote, t.AuthorName, t.AuthorTitle, t.Rating, t.Status, t.CreatedAt, t.ModeratedAt)
+				result.Testimonials.Created++
+			}
+			if err != nil {
+				return nil, errors.Wrap(ErrInternalServer, err.Error())
+			}
+		}
+
+		for kind, count := range project.Reactions {
+			_, err = tx.Exec(`
+				INSERT INTO project_reactions (project_id, kind, count) VALUES ($1, $2, $3)
+				ON CONFLICT (project_id, kind) DO UPDATE SET count = EXCLUDED.count`,
+				ID, kind, count)
+			if err != nil {
+				return nil, errors.Wrap(ErrInternalServer, err.Error())
+			}
+		}
+	}
+
+	for _, photo := range archive.Photos {
+		var projectID *int
+		if photo.ProjectID != nil {
+			ID := projectIDs[*photo.ProjectID]
+			projectID = &ID
+		}
+
+		ID, found, err := findID(tx, merge, `
+			SELECT id FROM project_photos WHERE project_id IS NOT DISTINCT FROM $1 AND photo IS NOT DISTINCT FROM $2
+			ORDER BY deleted_at NULLS FIRST, id LIMIT 1`, projectID, photo.Photo)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			_, err = tx.Exec("UPDATE project_photos SET description=$1, deleted_at=NULL WHERE id=$2", photo.Description, ID)
+			result.Photos.Updated++
+		} else {
+			_, err = tx.Exec("INSERT INTO project_photos (photo, description, project_id) VALUES ($1, $2, $3)", photo.Photo, photo.Description, projectID)
+			result.Photos.Created++
+		}
+		if err != nil {
+			return nil, errors.Wrap(ErrInternalServer, err.Error())
+		}
+	}
+
+	if dryRun {
+		return &result, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &result, nil
+}
+
+// wipe deletes the portfolio, trashed rows included, along with everything hanging off it
+func wipe(tx *sqlx.Tx) error {
+	tables := []string{
+		"project_tags",
+		"project_members",
+		"testimonials",
+		"project_reactions",
+		"project_links",
+		"project_photos",
+		"jastip_member_roles",
+		"skill_endorsements",
+		"skills",
+		"projects",
+		"jastip_members",
+		"roles",
+	}
+	for _, table := range tables {
+		_, err := tx.Exec("DELETE FROM " + table)
+		if err != nil {
+			return errors.Wrap(ErrInternalServer, err.Error())
+		}
+	}
+
+	for _, table := range []string{"slug_history", "translations", "revisions", "daily_views"} {
+		_, err := tx.Exec("DELETE FROM "+table+" WHERE entity_type = ANY($1)", pq.Array(entityTypes))
+		if err != nil {
+			return errors.Wrap(ErrInternalServer, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// findID looks up the row an archive entry merges into, nothing ever matches outside of merge mode
+func findID(tx *sqlx.Tx, merge bool, query string, args ...interface{}) (int, bool, error) {
+	if !merge {
+		return 0, false, nil
+	}
+
+	var ID int
+	err := tx.Get(&ID, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+		return 0, false, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return ID, true, nil
+}
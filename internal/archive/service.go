@@ -0,0 +1,509 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/link"
+	"github.com/rafimuhammad01/portofolio-api/internal/project"
+	"github.com/rafimuhammad01/portofolio-api/internal/reaction"
+	"github.com/rafimuhammad01/portofolio-api/internal/skill"
+	"github.com/rafimuhammad01/portofolio-api/internal/storage"
+	"github.com/rafimuhammad01/portofolio-api/internal/testimonial"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxManifestSize guards against a manifest that inflates far beyond what a portfolio needs
+const maxManifestSize = 32 << 20
+
+func NewService(repo Repo, store storage.BlobStore) Service {
+	return &service{
+		repo:  repo,
+		store: store,
+	}
+}
+
+type Service interface {
+	Export(w io.Writer, format string, ctx context.Context) error
+	Import(data []byte, mode string, dryRun bool, ctx context.Context) (*Result, []string, error)
+}
+
+type service struct {
+	repo  Repo
+	store storage.BlobStore
+}
+
+// Export writes the portfolio as a zip holding the manifest and every uploaded file it references.
+// Nothing is written to w when the portfolio can't be read.
+func (s service) Export(w io.Writer, format string, ctx context.Context) error {
+	archive, err := s.repo.Export()
+	if err != nil {
+		return err
+	}
+	archive.Version = Version
+	archive.ExportedAt = time.Now().UTC()
+
+	// Swap URLs of uploaded files for the file bundled in the archive
+	var keys []string
+	bundled := make(map[string]bool)
+	bundle := func(photo, photoFile **string) {
+		key, ok := s.fileKey(*photo)
+		if !ok {
+			return
+		}
+
+		blob, err := s.store.Get(ctx, key)
+		if err != nil {
+			// A missing file is exported as a dangling URL rather than failing the whole export
+			logrus.Warn("[error while bundling file in archive] ", err)
+			return
+		}
+		blob.Close()
+
+		if !bundled[key] {
+			bundled[key] = true
+			keys = append(keys, key)
+		}
+		*photo, *photoFile = nil, &key
+	}
+	for i := range archive.Members {
+		bundle(&archive.Members[i].Photo, &archive.Members[i].PhotoFile)
+	}
+	for i := range archive.Photos {
+		bundle(&archive.Photos[i].Photo, &archive.Photos[i].PhotoFile)
+	}
+
+	manifest, err := encode(archive, format)
+	if err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+
+	f, err := zw.Create(manifestName + "." + format)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	_, err = f.Write(manifest)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	for _, key := range keys {
+		err = s.writeFile(zw, key, ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = zw.Close()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+// Import reads an archive made by Export, validates all of it and only then writes it.
+// Problems with the archive itself come back as an error list, a dry run stops short of storing anything.
+func (s service) Import(data []byte, mode string, dryRun bool, ctx context.Context) (*Result, []string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, []string{"archive should be a zip file"}, nil
+	}
+
+	archive, errorList := decode(zr)
+	if len(errorList) != 0 {
+		return nil, errorList, nil
+	}
+
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		if strings.HasPrefix(f.Name, filesDir) {
+			files[strings.TrimPrefix(f.Name, filesDir)] = f
+		}
+	}
+
+	contents, errorList := readFiles(archive, files)
+	if len(errorList) != 0 {
+		return nil, errorList, nil
+	}
+
+	errorList = validate(archive)
+	if len(errorList) != 0 {
+		return nil, errorList, nil
+	}
+
+	// Bundled files get stored again under the URL this server serves them from
+	var stored []*storage.Image
+	urls := make(map[string]string, len(contents))
+	for key, content := range contents {
+		if dryRun {
+			urls[key] = s.store.URL(key)
+			continue
+		}
+
+		image, err := storage.SaveImage(ctx, s.store, content)
+		if err != nil {
+			discardImages(ctx, s.store, stored)
+			return nil, nil, err
+		}
+		stored = append(stored, image)
+		urls[key] = image.URL
+	}
+
+	for i := range archive.Members {
+		if archive.Members[i].PhotoFile != nil {
+			url := urls[*archive.Members[i].PhotoFile]
+			archive.Members[i].Photo = &url
+		}
+	}
+	for i := range archive.Photos {
+		if archive.Photos[i].PhotoFile != nil {
+			url := urls[*archive.Photos[i].PhotoFile]
+			archive.Photos[i].Photo = &url
+		}
+	}
+
+	result, err := s.repo.Import(archive, mode, dryRun)
+	if err != nil {
+		// Nothing references the files stored above once the import rolled back
+		discardImages(ctx, s.store, stored)
+		return nil, nil, err
+	}
+	result.Files = len(contents)
+
+	return result, nil, nil
+}
+
+// discardImages deletes the files an import stored, leaving alone those that were already in the store
+func discardImages(ctx context.Context, store storage.BlobStore, images []*storage.Image) {
+	for _, image := range images {
+		storage.DiscardImage(ctx, store, image)
+	}
+}
+
+// fileKey tells whether a photo URL points at a file in the blob store
+func (s service) fileKey(url *string) (string, bool) {
+	if url == nil {
+		return "", false
+	}
+
+	prefix := s.store.URL("")
+	if !strings.HasPrefix(*url, prefix) || *url == prefix {
+		return "", false
+	}
+
+	return strings.TrimPrefix(*url, prefix), true
+}
+
+func (s service) writeFile(zw *zip.Writer, key string, ctx context.Context) error {
+	blob, err := s.store.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	// Images are already compressed, deflating them again only costs time
+	f, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     filesDir + key,
+		Method:   zip.Store,
+		Modified: blob.ModTime,
+	})
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	_, err = io.Copy(f, blob)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+func encode(archive *Archive, format string) ([]byte, error) {
+	var (
+		manifest []byte
+		err      error
+	)
+
+	switch format {
+	case FormatYAML:
+		manifest, err = yaml.Marshal(archive)
+	default:
+		manifest, err = json.MarshalIndent(archive, "", "  ")
+	}
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return manifest, nil
+}
+
+// decode finds the manifest in the zip, whichever format it was exported in
+func decode(zr *zip.Reader) (*Archive, []string) {
+	var archive Archive
+
+	for _, f := range zr.File {
+		if f.Name != manifestName+"."+FormatJSON && f.Name != manifestName+"."+FormatYAML {
+			continue
+		}
+
+		manifest, err := readZipFile(f, maxManifestSize)
+		if err != nil {
+			return nil, []string{fmt.Sprintf("%s: %s", f.Name, err)}
+		}
+
+		if f.Name == manifestName+"."+FormatYAML {
+			err = yaml.Unmarshal(manifest, &archive)
+		} else {
+			err = json.Unmarshal(manifest, &archive)
+		}
+		if err != nil {
+			return nil, []string{fmt.Sprintf("%s: %s", f.Name, err)}
+		}
+
+		if archive.Version != Version {
+			return nil, []string{fmt.Sprintf("archive version %d is not supported, expected version %d", archive.Version, Version)}
+		}
+
+		return &archive, nil
+	}
+
+	return nil, []string{fmt.Sprintf("archive should contain %s.%s or %s.%s", manifestName, FormatJSON, manifestName, FormatYAML)}
+}
+
+// readFiles reads every file the archive references, making sure each is there and is an image
+func readFiles(archive *Archive, files map[string]*zip.File) (map[string][]byte, []string) {
+	var errorList []string
+	contents := make(map[string][]byte)
+
+	check := func(entry string, photoFile *string) {
+		if photoFile == nil {
+			return
+		}
+		if _, ok := contents[*photoFile]; ok {
+			return
+		}
+
+		f, ok := files[*photoFile]
+		if !ok {
+			errorList = append(errorList, fmt.Sprintf("%s: file %s is missing from the archive", entry, *photoFile))
+			return
+		}
+
+		content, err := readZipFile(f, utils.GetMaxUploadSize())
+		if err != nil {
+			errorList = append(errorList, fmt.Sprintf("%s: file %s: %s", entry, *photoFile, err))
+			return
+		}
+
+		if !storage.IsImage(content) {
+			errorList = append(errorList, fmt.Sprintf("%s: file %s: %s", entry, *photoFile, storage.ErrUnsupportedFileType))
+			return
+		}
+
+		contents[*photoFile] = content
+	}
+
+	for i, member := range archive.Members {
+		check(fmt.Sprintf("members[%d]", i), member.PhotoFile)
+	}
+	for i, photo := range archive.Photos {
+		check(fmt.Sprintf("photos[%d]", i), photo.PhotoFile)
+	}
+
+	return contents, errorList
+}
+
+func readZipFile(f *zip.File, maxSize int64) ([]byte, error) {
+	if f.UncompressedSize64 > uint64(maxSize) {
+		return nil, storage.ErrFileTooLarge
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	// The sizes in the zip header can lie
+	content, err := ioutil.ReadAll(io.LimitReader(rc, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(content)) > maxSize {
+		return nil, storage.ErrFileTooLarge
+	}
+
+	return content, nil
+}
+
+// validate checks the archive is complete and consistent before anything is written
+func validate(archive *Archive) []string {
+	var errorList []string
+	required := func(entry, field, value string, maxLength int) {
+		if value == "" {
+			errorList = append(errorList, fmt.Sprintf("%s: %s is required", entry, field))
+		}
+		if len(value) > maxLength {
+			errorList = append(errorList, fmt.Sprintf("%s: %s should be less than %d characters", entry, field, maxLength))
+		}
+	}
+	uniqueIDs := func(entry string, ID int, seen map[int]bool) {
+		if seen[ID] {
+			errorList = append(errorList, fmt.Sprintf("%s: id %d is used more than once", entry, ID))
+		}
+		seen[ID] = true
+	}
+
+	roles := make(map[int]bool)
+	for i, role := range archive.Roles {
+		entry := fmt.Sprintf("roles[%d]", i)
+		uniqueIDs(entry, role.ID, roles)
+		required(entry, "name", role.Name, 128)
+	}
+
+	members := make(map[int]bool)
+	memberSlugs := make(map[string]bool)
+	for i, member := range archive.Members {
+		entry := fmt.Sprintf("members[%d]", i)
+		uniqueIDs(entry, member.ID, members)
+		required(entry, "name", member.Name, 128)
+		required(entry, "slug", member.Slug, 160)
+		if memberSlugs[member.Slug] {
+			errorList = append(errorList, fmt.Sprintf("%s: slug %s is used more than once", entry, member.Slug))
+		}
+		memberSlugs[member.Slug] = true
+
+		for _, roleID := range member.RoleIDs {
+			if !roles[roleID] {
+				errorList = append(errorList, fmt.Sprintf("%s: role %d doesn't exist in the archive", entry, roleID))
+			}
+		}
+	}
+
+	for i, sk := range archive.Skills {
+		entry := fmt.Sprintf("skills[%d]", i)
+		required(entry, "skill", sk.Skill, 128)
+		if sk.Proficiency < skill.MinProficiency || sk.Proficiency > skill.MaxProficiency {
+			errorList = append(errorList, fmt.Sprintf("%s: proficiency should be between %d and %d", entry, skill.MinProficiency, skill.MaxProficiency))
+		}
+		if sk.YearsOfExperience < 0 || sk.YearsOfExperience > skill.MaxYearsOfExperience {
+			errorList = append(errorList, fmt.Sprintf("%s: years_of_experience should be between 0 and %d", entry, skill.MaxYearsOfExperience))
+		}
+		if sk.MemberID != nil && !members[*sk.MemberID] {
+			errorList = append(errorList, fmt.Sprintf("%s: member %d doesn't exist in the archive", entry, *sk.MemberID))
+		}
+		for _, username := range sk.Endorsements {
+			required(entry, "endorsement username", username, 128)
+		}
+	}
+
+	projects := make(map[int]bool)
+	projectSlugs := make(map[string]bool)
+	for i, p := range archive.Projects {
+		entry := fmt.Sprintf("projects[%d]", i)
+		uniqueIDs(entry, p.ID, projects)
+		required(entry, "name", p.Name, 128)
+		required(entry, "client_name", p.ClientName, 128)
+		required(entry, "slug", p.Slug, 160)
+		if projectSlugs[p.Slug] {
+			errorList = append(errorList, fmt.Sprintf("%s: slug %s is used more than once", entry, p.Slug))
+		}
+		projectSlugs[p.Slug] = true
+
+		if p.Status != project.StatusDraft && p.Status != project.StatusPublished && p.Status != project.StatusArchived {
+			errorList = append(errorList, fmt.Sprintf("%s: status should be one of draft, published or archived", entry))
+		}
+
+		for _, tag := range p.Tags {
+			if utils.Slugify(tag) == "" || len(tag) > 64 {
+				errorList = append(errorList, fmt.Sprintf("%s: tag %q should have a letter or digit and be less than 64 characters", entry, tag))
+			}
+		}
+
+		team := make(map[int]bool)
+		for _, member := range p.Team {
+			if !members[member.MemberID] {
+				errorList = append(errorList, fmt.Sprintf("%s: member %d doesn't exist in the archive", entry, member.MemberID))
+			}
+			if team[member.MemberID] {
+				errorList = append(errorList, fmt.Sprintf("%s: member %d is on the team more than once", entry, member.MemberID))
+			}
+			team[member.MemberID] = true
+			required(entry, "team role", member.Role, 128)
+		}
+
+		urls := make(map[string]bool)
+		for _, l := range p.Links {
+			if !link.IsType(l.Type) {
+				errorList = append(errorList, fmt.Sprintf("%s: link type should be one of %s", entry, strings.Join(link.Types, ", ")))
+			}
+			if l.Label != nil && len(*l.Label) > link.MaxLabelLength {
+				errorList = append(errorList, fmt.Sprintf("%s: link label should be less than %d characters", entry, link.MaxLabelLength))
+			}
+			if !link.IsValidURL(l.URL) {
+				errorList = append(errorList, fmt.Sprintf("%s: link url %q should be an absolute http or https url", entry, l.URL))
+			}
+			if urls[l.URL] {
+				errorList = append(errorList, fmt.Sprintf("%s: link url %q is used more than once", entry, l.URL))
+			}
+			urls[l.URL] = true
+		}
+
+		for j, t := range p.Testimonials {
+			testimonialEntry := fmt.Sprintf("%s.testimonials[%d]", entry, j)
+			required(testimonialEntry, "quote", t.Quote, 2000)
+			required(testimonialEntry, "author_name", t.AuthorName, 128)
+			if t.AuthorTitle != nil && len(*t.AuthorTitle) > 128 {
+				errorList = append(errorList, fmt.Sprintf("%s: author_title should be less than 128 characters", testimonialEntry))
+			}
+			if t.Rating < 1 || t.Rating > 5 {
+				errorList = append(errorList, fmt.Sprintf("%s: rating should be between 1 and 5", testimonialEntry))
+			}
+			if t.Status != testimonial.StatusPending && t.Status != testimonial.StatusApproved && t.Status != testimonial.StatusRejected {
+				errorList = append(errorList, fmt.Sprintf("%s: status should be one of pending, approved or rejected", testimonialEntry))
+			}
+			if t.CreatedAt.IsZero() {
+				errorList = append(errorList, fmt.Sprintf("%s: created_at is required", testimonialEntry))
+			}
+		}
+
+		kinds := make([]string, 0, len(p.Reactions))
+		for kind := range p.Reactions {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		for _, kind := range kinds {
+			if !reaction.IsKind(kind) {
+				errorList = append(errorList, fmt.Sprintf("%s: reaction should be one of %s", entry, strings.Join(reaction.Kinds, ", ")))
+			}
+			if p.Reactions[kind] < 0 {
+				errorList = append(errorList, fmt.Sprintf("%s: %s reactions shouldn't be negative", entry, kind))
+			}
+		}
+	}
+
+	for i, photo := range archive.Photos {
+		entry := fmt.Sprintf("photos[%d]", i)
+		if photo.ProjectID != nil && !projects[*photo.ProjectID] {
+			errorList = append(errorList, fmt.Sprintf("%s: project %d doesn't exist in the archive", entry, *photo.ProjectID))
+		}
+	}
+
+	return errorList
+}
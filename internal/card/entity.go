@@ -0,0 +1,41 @@
+package card
+
+import "github.com/rafimuhammad01/portofolio-api/internal/storage"
+
+const (
+	// Open Graph images are shown at 1.91:1
+	cardWidth  = 1200
+	cardHeight = 630
+
+	// cardVersion goes into the content hash, bump it when the layout changes so cached cards get redrawn
+	cardVersion = 1
+
+	// cacheDir is where rendered cards are kept in the blob store, keyed by content hash
+	cacheDir = "cards/"
+)
+
+// Card is everything drawn on a card, its content hash decides whether a cached card is still good
+type Card struct {
+	Version  int      `json:"version"`
+	SiteName string   `json:"site_name"`
+	Title    string   `json:"title" db:"title"`
+	Subtitle string   `json:"subtitle" db:"subtitle"`
+	Photo    *string  `json:"photo" db:"photo"`
+	Tags     []string `json:"tags" db:"-"`
+	// PhotoMissing marks a card drawn without its photo because loading it failed,
+	// it's left out of the hash of complete cards so their cache keys don't change
+	PhotoMissing bool `json:"photo_missing,omitempty" db:"-"`
+}
+
+// Rendered is a card PNG read from the cache, the caller has to close Blob
+type Rendered struct {
+	Hash string
+	Blob *storage.Blob
+}
+
+// CardAPIResponse API response for Card, only used when there's no card to serve
+type CardAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Errors  []string `json:"errors,omitempty"`
+}
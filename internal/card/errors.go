@@ -0,0 +1,9 @@
+package card
+
+import "github.com/pkg/errors"
+
+var (
+	ErrProjectNotFound = errors.New("project not found")
+	ErrMemberNotFound  = errors.New("member not found")
+	ErrInternalServer  = errors.New("internal server error")
+)
@@ -0,0 +1,69 @@
+package card
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// GetProjectCard serves the Open Graph image of a published project, by id or slug
+func (h *Handler) GetProjectCard(c *gin.Context) {
+	res, err := h.service.ProjectCard(c.Param("id"), c)
+	if err != nil {
+		if errors.Cause(err) == ErrProjectNotFound {
+			c.JSON(http.StatusNotFound, &CardAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrProjectNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using project card service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	serveCard(c, res)
+}
+
+// GetMemberCard serves the Open Graph image of a member, by id or slug
+func (h *Handler) GetMemberCard(c *gin.Context) {
+	res, err := h.service.MemberCard(c.Param("id"), c)
+	if err != nil {
+		if errors.Cause(err) == ErrMemberNotFound {
+			c.JSON(http.StatusNotFound, &CardAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrMemberNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using member card service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	serveCard(c, res)
+}
+
+// serveCard lets clients revalidate against the content hash, the URL stays the same when the card changes
+func serveCard(c *gin.Context, res *Rendered) {
+	defer res.Blob.Close()
+
+	c.Header("Content-Type", "image/png")
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.Header("ETag", `"`+res.Hash+`"`)
+	http.ServeContent(c.Writer, c.Request, "", res.Blob.ModTime, res.Blob)
+}
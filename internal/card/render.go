@@ -0,0 +1,226 @@
+package card
+
+import (
+	"bytes"
+	"github.com/pkg/errors"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+const (
+	padding    = 64
+	photoWidth = 480
+	tagPadding = 14
+	tagGap     = 12
+	maxTitle   = 3
+	maxTagRows = 2
+)
+
+var (
+	background = color.RGBA{R: 0x0f, G: 0x17, B: 0x2a, A: 0xff}
+	foreground = color.RGBA{R: 0xf8, G: 0xfa, B: 0xfc, A: 0xff}
+	muted      = color.RGBA{R: 0x94, G: 0xa3, B: 0xb8, A: 0xff}
+	accent     = color.RGBA{R: 0x38, G: 0xbd, B: 0xf8, A: 0xff}
+	tagFill    = color.RGBA{R: 0x1e, G: 0x29, B: 0x3b, A: 0xff}
+)
+
+// The Go fonts are compiled into the binary, so cards look the same wherever the API runs
+var (
+	regularFont = mustParseFont(goregular.TTF)
+	boldFont    = mustParseFont(gobold.TTF)
+)
+
+func mustParseFont(ttf []byte) *opentype.Font {
+	f, err := opentype.Parse(ttf)
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+}
+
+func newFace(f *opentype.Font, size float64) (font.Face, error) {
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return face, nil
+}
+
+// paint draws the card as a PNG: text on the left, the photo cropped to fill the right side
+func paint(card *Card, photo image.Image) ([]byte, error) {
+	dst := image.NewRGBA(image.Rect(0, 0, cardWidth, cardHeight))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	textRight := cardWidth - padding
+	if photo != nil {
+		area := image.Rect(cardWidth-photoWidth, 0, cardWidth, cardHeight)
+		draw.CatmullRom.Scale(dst, area, photo, cover(photo.Bounds(), area), draw.Src, nil)
+		textRight = area.Min.X - padding
+	}
+	textWidth := textRight - padding
+
+	siteFace, err := newFace(boldFont, 28)
+	if err != nil {
+		return nil, err
+	}
+	titleFace, err := newFace(boldFont, 64)
+	if err != nil {
+		return nil, err
+	}
+	subtitleFace, err := newFace(regularFont, 36)
+	if err != nil {
+		return nil, err
+	}
+	tagFace, err := newFace(regularFont, 26)
+	if err != nil {
+		return nil, err
+	}
+
+	y := padding + ascent(siteFace)
+	drawText(dst, siteFace, accent, padding, y, truncate(siteFace, strings.ToUpper(card.SiteName), textWidth))
+
+	y += 40
+	for _, line := range wrap(titleFace, card.Title, textWidth, maxTitle) {
+		y += lineHeight(titleFace)
+		drawText(dst, titleFace, foreground, padding, y, line)
+	}
+
+	if card.Subtitle != "" {
+		y += 16 + lineHeight(subtitleFace)
+		drawText(dst, subtitleFace, muted, padding, y, truncate(subtitleFace, card.Subtitle, textWidth))
+	}
+
+	drawTags(dst, tagFace, card.Tags, textWidth)
+
+	var buf bytes.Buffer
+	err = png.Encode(&buf, dst)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return buf.Bytes(), nil
+}
+
+// drawTags lays the tags out as pills from the bottom of the card up, dropping whatever doesn't fit
+func drawTags(dst *image.RGBA, face font.Face, tags []string, width int) {
+	pillHeight := lineHeight(face) + tagPadding
+	var rows [][]string
+	var row []string
+	rowWidth := 0
+	for _, tag := range tags {
+		tag = truncate(face, tag, width-2*tagPadding)
+		w := font.MeasureString(face, tag).Ceil() + 2*tagPadding
+		if len(row) != 0 && rowWidth+tagGap+w > width {
+			rows = append(rows, row)
+			row, rowWidth = nil, 0
+		}
+		if len(row) != 0 {
+			rowWidth += tagGap
+		}
+		row = append(row, tag)
+		rowWidth += w
+	}
+	if len(row) != 0 {
+		rows = append(rows, row)
+	}
+	if len(rows) > maxTagRows {
+		rows = rows[:maxTagRows]
+	}
+
+	top := cardHeight - padding - len(rows)*pillHeight - (len(rows)-1)*tagGap
+	for i, row := range rows {
+		x := padding
+		y := top + i*(pillHeight+tagGap)
+		for _, tag := range row {
+			w := font.MeasureString(face, tag).Ceil() + 2*tagPadding
+			draw.Draw(dst, image.Rect(x, y, x+w, y+pillHeight), image.NewUniform(tagFill), image.Point{}, draw.Src)
+			drawText(dst, face, foreground, x+tagPadding, y+tagPadding/2+ascent(face), tag)
+			x += w + tagGap
+		}
+	}
+}
+
+func drawText(dst *image.RGBA, face font.Face, c color.Color, x, y int, text string) {
+	d := font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
+
+// wrap breaks text into at most maxLines lines fitting width, the last one is cut short when there's more
+func wrap(face font.Face, text string, width, maxLines int) []string {
+	var lines []string
+	line := ""
+	words := strings.Fields(text)
+	for i, word := range words {
+		candidate := strings.TrimSpace(line + " " + word)
+		if line == "" || font.MeasureString(face, candidate).Ceil() <= width {
+			line = candidate
+			continue
+		}
+
+		if len(lines) == maxLines-1 {
+			return append(lines, truncate(face, strings.Join(append([]string{line}, words[i:]...), " "), width))
+		}
+		lines = append(lines, truncate(face, line, width))
+		line = word
+	}
+	if line != "" {
+		lines = append(lines, truncate(face, line, width))
+	}
+
+	return lines
+}
+
+// truncate shortens text with an ellipsis until it fits width
+func truncate(face font.Face, text string, width int) string {
+	if font.MeasureString(face, text).Ceil() <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "…"
+		if font.MeasureString(face, candidate).Ceil() <= width {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+// cover picks the part of src that fills dst without distorting it, from the center
+func cover(src, dst image.Rectangle) image.Rectangle {
+	srcWidth, srcHeight := src.Dx(), src.Dy()
+	if srcWidth*dst.Dy() > srcHeight*dst.Dx() {
+		width := srcHeight * dst.Dx() / dst.Dy()
+		x := src.Min.X + (srcWidth-width)/2
+		return image.Rect(x, src.Min.Y, x+width, src.Max.Y)
+	}
+
+	height := srcWidth * dst.Dy() / dst.Dx()
+	y := src.Min.Y + (srcHeight-height)/2
+	return image.Rect(src.Min.X, y, src.Max.X, y+height)
+}
+
+func ascent(face font.Face) int {
+	return face.Metrics().Ascent.Ceil()
+}
+
+func lineHeight(face font.Face) int {
+	return face.Metrics().Height.Ceil()
+}
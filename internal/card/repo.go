@@ -0,0 +1,90 @@
+package card
+
+import (
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// maxTags is as many tags as could ever fit on a card
+const maxTags = 12
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+type Repo interface {
+	GetProject(idOrSlug string) (*Card, error)
+	GetMember(idOrSlug string) (*Card, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+// GetProject reads a published project's name, client, first photo and tags
+func (r repo) GetProject(idOrSlug string) (*Card, error) {
+	var card struct {
+		ID int `db:"id"`
+		Card
+	}
+	err := r.db.Get(&card, `
+		SELECT p.id, p.name AS title, p.client_name AS subtitle, (
+			SELECT photo FROM project_photos WHERE project_id = p.id AND photo IS NOT NULL AND deleted_at IS NULL ORDER BY id LIMIT 1
+		) AS photo
+		FROM projects p
+		WHERE (p.slug = $1 OR p.id::text = $1) AND p.deleted_at IS NULL AND p.status = 'published'`, idOrSlug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrProjectNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	card.Tags = []string{}
+	err = r.db.Select(&card.Tags, `
+		SELECT t.name FROM project_tags pt
+		JOIN tags t ON t.id = pt.tag_id
+		WHERE pt.project_id = $1
+		ORDER BY t.name LIMIT $2`, card.ID, maxTags)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &card.Card, nil
+}
+
+// GetMember reads a member's name, roles, photo and skills
+func (r repo) GetMember(idOrSlug string) (*Card, error) {
+	var card struct {
+		ID int `db:"id"`
+		Card
+	}
+	err := r.db.Get(&card, `
+		SELECT m.id, m.name AS title, m.photo, COALESCE((
+			SELECT string_agg(DISTINCT ro.name, ', ') FROM jastip_member_roles mr
+			JOIN roles ro ON ro.id = mr.role_id AND ro.deleted_at IS NULL
+			WHERE mr.jastip_member_id = m.id
+		), '') AS subtitle
+		FROM jastip_members m
+		WHERE (m.slug = $1 OR m.id::text = $1) AND m.deleted_at IS NULL`, idOrSlug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrMemberNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	card.Tags = []string{}
+	err = r.db.Select(&card.Tags, `
+		SELECT skill FROM skills WHERE jastip_member_id = $1 AND deleted_at IS NULL
+		ORDER BY rank DESC, id LIMIT $2`, card.ID, maxTags)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &card.Card, nil
+}
@@ -0,0 +1,135 @@
+package card
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/media"
+	"github.com/rafimuhammad01/portofolio-api/internal/storage"
+	"github.com/sirupsen/logrus"
+	"image"
+)
+
+func NewService(repo Repo, store storage.BlobStore, mediaService media.Service, siteName string) Service {
+	return &service{
+		repo:         repo,
+		store:        store,
+		mediaService: mediaService,
+		siteName:     siteName,
+	}
+}
+
+type Service interface {
+	ProjectCard(idOrSlug string, ctx context.Context) (*Rendered, error)
+	MemberCard(idOrSlug string, ctx context.Context) (*Rendered, error)
+}
+
+type service struct {
+	repo         Repo
+	store        storage.BlobStore
+	mediaService media.Service
+	siteName     string
+}
+
+func (s service) ProjectCard(idOrSlug string, ctx context.Context) (*Rendered, error) {
+	card, err := s.repo.GetProject(idOrSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.render(card, ctx)
+}
+
+func (s service) MemberCard(idOrSlug string, ctx context.Context) (*Rendered, error) {
+	card, err := s.repo.GetMember(idOrSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.render(card, ctx)
+}
+
+// render draws a card only when no card with the same content has been drawn before.
+// A card drawn without a photo that failed to load is cached apart from the complete card,
+// which is still looked for first, so the photo is tried again until it loads.
+func (s service) render(card *Card, ctx context.Context) (*Rendered, error) {
+	card.Version = cardVersion
+	card.SiteName = s.siteName
+
+	rendered, hash, err := s.cached(card, ctx)
+	if rendered != nil || err != nil {
+		return rendered, err
+	}
+
+	photo, ok := s.loadPhoto(card, ctx)
+	if !ok {
+		card.PhotoMissing = true
+		rendered, hash, err = s.cached(card, ctx)
+		if rendered != nil || err != nil {
+			return rendered, err
+		}
+	}
+
+	img, err := paint(card, photo)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.Put(ctx, cacheKey(hash), bytes.NewReader(img))
+	if err != nil {
+		return nil, err
+	}
+
+	blob, err := s.store.Get(ctx, cacheKey(hash))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rendered{Hash: hash, Blob: blob}, nil
+}
+
+// cached looks up the card by its content hash, which is returned either way
+func (s service) cached(card *Card, ctx context.Context) (*Rendered, string, error) {
+	content, err := json.Marshal(card)
+	if err != nil {
+		return nil, "", errors.Wrap(ErrInternalServer, err.Error())
+	}
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	blob, err := s.store.Get(ctx, cacheKey(hash))
+	if err == nil {
+		return &Rendered{Hash: hash, Blob: blob}, hash, nil
+	}
+	if errors.Cause(err) != storage.ErrBlobNotFound {
+		return nil, "", err
+	}
+
+	return nil, hash, nil
+}
+
+func cacheKey(hash string) string {
+	return cacheDir + hash[:2] + "/" + hash + ".png"
+}
+
+// loadPhoto decodes the card photo. A card is still worth drawing without it, which is what
+// happens to photos hosted elsewhere. ok is false when the photo is ours but couldn't be loaded.
+func (s service) loadPhoto(card *Card, ctx context.Context) (img image.Image, ok bool) {
+	if card.Photo == nil {
+		return nil, true
+	}
+
+	img, err := s.mediaService.Load(ctx, *card.Photo, cardWidth)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrInvalidKey {
+			return nil, true
+		}
+		logrus.Warn("[error while loading card photo] ", err)
+		return nil, false
+	}
+
+	return img, true
+}
@@ -0,0 +1,57 @@
+package feed
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) GetProjectsAtom(c *gin.Context) {
+	h.serveProjects(c, "application/atom+xml; charset=utf-8", renderAtom)
+}
+
+func (h *Handler) GetProjectsRSS(c *gin.Context) {
+	h.serveProjects(c, "application/rss+xml; charset=utf-8", renderRSS)
+}
+
+func (h *Handler) GetProjectsJSON(c *gin.Context) {
+	h.serveProjects(c, "application/feed+json; charset=utf-8", renderJSON)
+}
+
+// serveProjects answers conditional requests with 304 Not Modified, matching
+// If-None-Match against a hash of the feed and If-Modified-Since against its last change
+func (h *Handler) serveProjects(c *gin.Context, contentType string, render func(*Feed) ([]byte, error)) {
+	feed, err := h.service.Projects()
+	if err != nil {
+		logrus.Error("[error while using projects feed service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	body, err := render(feed)
+	if err != nil {
+		logrus.Error("[error while rendering projects feed] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	c.Header("Content-Type", contentType)
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Header("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	http.ServeContent(c.Writer, c.Request, "", feed.Updated, bytes.NewReader(body))
+}
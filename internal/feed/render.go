@@ -0,0 +1,188 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/storage"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// links are the absolute URLs a feed refers to
+type links struct {
+	home string
+	self string
+}
+
+func projectLinks(format string) links {
+	return links{
+		home: utils.GetSiteURL() + "/projects",
+		self: utils.GetAPIURL() + "/api/v1/feeds/projects." + format,
+	}
+}
+
+// entryID stays the same when a project is renamed, unlike its slug
+func entryID(entry Entry) string {
+	return utils.GetAPIURL() + "/api/v1/projects/" + strconv.Itoa(entry.ID)
+}
+
+func entryURL(entry Entry) string {
+	return utils.GetSiteURL() + "/projects/" + url.PathEscape(entry.Slug)
+}
+
+func entrySummary(entry Entry) string {
+	if entry.Description != nil && *entry.Description != "" {
+		return *entry.Description
+	}
+
+	return entry.ClientName
+}
+
+func photoURL(photo Photo) string {
+	return utils.AbsoluteURL(utils.GetAPIURL(), photo.Photo)
+}
+
+func photoType(photo Photo) string {
+	u, err := url.Parse(photo.Photo)
+	if err != nil {
+		return "application/octet-stream"
+	}
+
+	return storage.ContentType(u.Path)
+}
+
+func renderAtom(feed *Feed) ([]byte, error) {
+	l := projectLinks("atom")
+	doc := atomFeed{
+		Title:   utils.GetSiteName() + " projects",
+		ID:      l.self,
+		Updated: feed.Updated.UTC().Format(time.RFC3339),
+		Author:  atomPerson{Name: utils.GetSiteName()},
+		Links: []atomLink{
+			{Href: l.self, Rel: "self", Type: "application/atom+xml"},
+			{Href: l.home, Rel: "alternate", Type: "text/html"},
+		},
+	}
+
+	for _, entry := range feed.Entries {
+		e := atomEntry{
+			Title:     entry.Name,
+			ID:        entryID(entry),
+			Published: entry.PublishedAt.UTC().Format(time.RFC3339),
+			Updated:   entry.UpdatedAt.UTC().Format(time.RFC3339),
+			Links:     []atomLink{{Href: entryURL(entry), Rel: "alternate", Type: "text/html"}},
+			Summary:   &atomText{Type: "text", Text: entrySummary(entry)},
+		}
+
+		for _, photo := range entry.Photos {
+			link := atomLink{Href: photoURL(photo), Rel: "enclosure", Type: photoType(photo)}
+			if photo.Description != nil {
+				link.Title = *photo.Description
+			}
+			e.Links = append(e.Links, link)
+		}
+
+		for _, tag := range entry.Tags {
+			e.Categories = append(e.Categories, atomCategory{Term: tag})
+		}
+
+		doc.Entries = append(doc.Entries, e)
+	}
+
+	return marshalXML(doc)
+}
+
+func renderRSS(feed *Feed) ([]byte, error) {
+	l := projectLinks("rss")
+	doc := rssFeed{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:       utils.GetSiteName() + " projects",
+			Link:        l.home,
+			Description: "Newly published projects by " + utils.GetSiteName(),
+			AtomLink:    atomLink{Href: l.self, Rel: "self", Type: "application/rss+xml"},
+		},
+	}
+	if !feed.Updated.IsZero() {
+		doc.Channel.LastBuildDate = feed.Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, entry := range feed.Entries {
+		item := rssItem{
+			Title:       entry.Name,
+			Link:        entryURL(entry),
+			GUID:        rssGUID{Value: entryID(entry)},
+			PubDate:     entry.PublishedAt.UTC().Format(time.RFC1123Z),
+			Description: entrySummary(entry),
+			Categories:  entry.Tags,
+		}
+
+		if len(entry.Photos) != 0 {
+			item.Enclosure = &rssEnclosure{
+				URL:  photoURL(entry.Photos[0]),
+				Type: photoType(entry.Photos[0]),
+			}
+		}
+
+		doc.Channel.Items = append(doc.Channel.Items, item)
+	}
+
+	return marshalXML(doc)
+}
+
+func renderJSON(feed *Feed) ([]byte, error) {
+	l := projectLinks("json")
+	doc := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       utils.GetSiteName() + " projects",
+		HomePageURL: l.home,
+		FeedURL:     l.self,
+		Items:       []jsonItem{},
+	}
+
+	for _, entry := range feed.Entries {
+		item := jsonItem{
+			ID:            entryID(entry),
+			URL:           entryURL(entry),
+			Title:         entry.Name,
+			ContentText:   entrySummary(entry),
+			DatePublished: entry.PublishedAt.UTC().Format(time.RFC3339),
+			DateModified:  entry.UpdatedAt.UTC().Format(time.RFC3339),
+			Tags:          entry.Tags,
+		}
+
+		for i, photo := range entry.Photos {
+			if i == 0 {
+				item.Image = photoURL(photo)
+			}
+
+			attachment := jsonAttachment{URL: photoURL(photo), MimeType: photoType(photo)}
+			if photo.Description != nil {
+				attachment.Title = *photo.Description
+			}
+			item.Attachments = append(item.Attachments, attachment)
+		}
+
+		doc.Items = append(doc.Items, item)
+	}
+
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return body, nil
+}
+
+func marshalXML(doc interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
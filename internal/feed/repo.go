@@ -0,0 +1,82 @@
+package feed
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+type Repo interface {
+	ListPublished(limit int) ([]Entry, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+// ListPublished lists the newest published projects along with their tags and photos
+func (r repo) ListPublished(limit int) ([]Entry, error) {
+	entries := []Entry{}
+	err := r.db.Select(&entries, `
+		SELECT p.id, p.name, p.slug, p.client_name, p.description,
+			COALESCE(p.publish_at, NOW()) AS published_at,
+			GREATEST(COALESCE(p.publish_at, NOW()), rev.created_at) AS updated_at
+		FROM projects p
+		LEFT JOIN LATERAL (
+			SELECT MAX(created_at) AS created_at FROM revisions WHERE entity_type = 'project' AND entity_id = p.id
+		) rev ON TRUE
+		WHERE p.deleted_at IS NULL AND p.status = 'published'
+		ORDER BY p.publish_at DESC NULLS LAST, p.id DESC
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	if len(entries) == 0 {
+		return entries, nil
+	}
+
+	IDs := make([]int64, len(entries))
+	index := make(map[int]*Entry, len(entries))
+	for i := range entries {
+		IDs[i] = int64(entries[i].ID)
+		index[entries[i].ID] = &entries[i]
+	}
+
+	var tags []struct {
+		ProjectID int    `db:"project_id"`
+		Name      string `db:"name"`
+	}
+	err = r.db.Select(&tags, `
+		SELECT pt.project_id, t.name FROM project_tags pt
+		JOIN tags t ON t.id = pt.tag_id
+		WHERE pt.project_id = ANY($1)
+		ORDER BY t.name`, pq.Array(IDs))
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	for _, tag := range tags {
+		index[tag.ProjectID].Tags = append(index[tag.ProjectID].Tags, tag.Name)
+	}
+
+	var photos []Photo
+	err = r.db.Select(&photos, `
+		SELECT project_id, photo, description FROM project_photos
+		WHERE project_id = ANY($1) AND photo IS NOT NULL AND deleted_at IS NULL
+		ORDER BY id`, pq.Array(IDs))
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	for _, photo := range photos {
+		index[photo.ProjectID].Photos = append(index[photo.ProjectID].Photos, photo)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,37 @@
+package feed
+
+import "time"
+
+func NewService(repo Repo) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+type Service interface {
+	Projects() (*Feed, error)
+}
+
+type service struct {
+	repo Repo
+}
+
+// Projects is the feed of the newest published projects
+func (s service) Projects() (*Feed, error) {
+	entries, err := s.repo.ListPublished(feedSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated time.Time
+	for _, entry := range entries {
+		if entry.UpdatedAt.After(updated) {
+			updated = entry.UpdatedAt
+		}
+	}
+
+	return &Feed{
+		Entries: entries,
+		Updated: updated,
+	}, nil
+}
@@ -0,0 +1,143 @@
+package inquiry
+
+import (
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"time"
+)
+
+// Inquiry status in the inbox
+const (
+	StatusNew      = "new"
+	StatusRead     = "read"
+	StatusArchived = "archived"
+)
+
+// Inquiry entity represent inquiries table in database
+type Inquiry struct {
+	ID        int        `json:"id" db:"id"`
+	Name      string     `json:"name" db:"name"`
+	Email     string     `json:"email" db:"email"`
+	Company   *string    `json:"company" db:"company"`
+	Subject   *string    `json:"subject" db:"subject"`
+	Message   string     `json:"message" db:"message"`
+	Status    string     `json:"status" db:"status"`
+	IPAddress string     `json:"ip_address" db:"ip_address"`
+	UserAgent *string    `json:"user_agent" db:"user_agent"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	ReadAt    *time.Time `json:"read_at" db:"read_at"`
+}
+
+// Note entity represent inquiry_notes table in database, used to track replies to an inquiry
+type Note struct {
+	ID        int       `json:"id" db:"id"`
+	UserID    *int      `json:"user_id" db:"user_id"`
+	Username  *string   `json:"username" db:"username"`
+	Note      string    `json:"note" db:"note"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Detail is an inquiry along with its notes
+type Detail struct {
+	Inquiry
+	Notes []Note `json:"notes"`
+}
+
+// Submission is what a visitor sent through the contact form
+type Submission struct {
+	Name      string
+	Email     string
+	Company   *string
+	Subject   *string
+	Message   string
+	IPAddress string
+	UserAgent string
+}
+
+type ListInquiry struct {
+	Inquiries []Inquiry `json:"inquiries"`
+	Count     int       `json:"count"`
+	listquery.Page
+}
+
+// listConfig whitelists sort and filter parameters for List
+var listConfig = listquery.Config{
+	Fields: map[string]listquery.Field{
+		"id":         {Column: "id", Type: listquery.TypeInt, Sortable: true},
+		"email":      {Column: "email", Filterable: true},
+		"status":     {Column: "status", Filterable: true},
+		"created_at": {Column: "created_at", Type: listquery.TypeTime, Sortable: true, Filterable: true},
+	},
+	IDField:      "id",
+	DefaultSort:  "-created_at",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// ListInquiryAPIResponse API response for List
+type ListInquiryAPIResponse struct {
+	Status  int          `json:"status"`
+	Message string       `json:"message"`
+	Data    *ListInquiry `json:"data,omitempty"`
+	Errors  []string     `json:"errors,omitempty"`
+}
+
+type GetInquiryByIDAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Detail  `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// FormToken is handed to the contact form before it is filled in
+type FormToken struct {
+	Token string `json:"form_token"`
+}
+
+type FormTokenAPIResponse struct {
+	Status  int        `json:"status"`
+	Message string     `json:"message"`
+	Data    *FormToken `json:"data,omitempty"`
+	Errors  []string   `json:"errors,omitempty"`
+}
+
+// SubmitInquiryAPIRequest contact form request body from client.
+// Website is a honeypot, it is hidden from humans so only bots fill it in.
+type SubmitInquiryAPIRequest struct {
+	Name      string  `json:"name"`
+	Email     string  `json:"email"`
+	Company   *string `json:"company"`
+	Subject   *string `json:"subject"`
+	Message   string  `json:"message"`
+	Website   string  `json:"website"`
+	FormToken string  `json:"form_token"`
+}
+
+type SubmitInquiryAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// UpdateStatusAPIRequest update inquiry status request body from client
+type UpdateStatusAPIRequest struct {
+	Status string `json:"status"`
+}
+
+type UpdateStatusAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Inquiry `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// AddNoteAPIRequest add note request body from client
+type AddNoteAPIRequest struct {
+	Note string `json:"note"`
+}
+
+type AddNoteAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Note    `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
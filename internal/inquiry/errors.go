@@ -0,0 +1,12 @@
+package inquiry
+
+import "github.com/pkg/errors"
+
+var (
+	ErrInquiryNotFound  = errors.New("inquiry not found")
+	ErrSpamDetected     = errors.New("submission looks like spam")
+	ErrInvalidFormToken = errors.New("form token is invalid or expired, please reload the form")
+	ErrSubmittedTooFast = errors.New("form was submitted too quickly, please try again")
+	ErrTooManyRequests  = errors.New("too many inquiries, please try again later")
+	ErrInternalServer   = errors.New("internal server error")
+)
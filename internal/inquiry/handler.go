@@ -0,0 +1,303 @@
+package inquiry
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) GetFormToken(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, &FormTokenAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    &FormToken{Token: h.service.FormToken()},
+	})
+}
+
+func (h *Handler) SubmitInquiry(c *gin.Context) {
+	var (
+		errorList   []string
+		requestBody SubmitInquiryAPIRequest
+	)
+
+	// Input Validation
+	err := c.ShouldBindJSON(&requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	requestBody.Name = strings.TrimSpace(requestBody.Name)
+	requestBody.Message = strings.TrimSpace(requestBody.Message)
+
+	if requestBody.Name == "" {
+		errorList = append(errorList, "name is required")
+	}
+
+	if len(requestBody.Name) > 128 {
+		errorList = append(errorList, "name should be less than 128 characters")
+	}
+
+	if requestBody.Email == "" {
+		errorList = append(errorList, "email is required")
+	} else if address, err := mail.ParseAddress(requestBody.Email); err != nil || address.Address != requestBody.Email || len(requestBody.Email) > 254 {
+		errorList = append(errorList, "email is invalid")
+	}
+
+	if requestBody.Company != nil && len(*requestBody.Company) > 128 {
+		errorList = append(errorList, "company should be less than 128 characters")
+	}
+
+	if requestBody.Subject != nil && len(*requestBody.Subject) > 200 {
+		errorList = append(errorList, "subject should be less than 200 characters")
+	}
+
+	if len(requestBody.Message) < 10 {
+		errorList = append(errorList, "message should be at least 10 characters")
+	}
+
+	if len(requestBody.Message) > 5000 {
+		errorList = append(errorList, "message should be less than 5000 characters")
+	}
+
+	if requestBody.FormToken == "" {
+		errorList = append(errorList, "form_token is required")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &SubmitInquiryAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	submission := Submission{
+		Name:      requestBody.Name,
+		Email:     requestBody.Email,
+		Company:   requestBody.Company,
+		Subject:   requestBody.Subject,
+		Message:   requestBody.Message,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+
+	_, err = h.service.Submit(submission, requestBody.Website, requestBody.FormToken, c)
+	if err != nil {
+		switch errors.Cause(err) {
+		case ErrSpamDetected:
+			// Don't let bots know they were caught
+			logrus.Info("[inquiry dropped] ", err)
+		case ErrInvalidFormToken, ErrSubmittedTooFast:
+			c.JSON(http.StatusBadRequest, &SubmitInquiryAPIResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+				Errors:  []string{errors.Cause(err).Error()},
+			})
+			return
+		case ErrTooManyRequests:
+			c.JSON(http.StatusTooManyRequests, &SubmitInquiryAPIResponse{
+				Status:  http.StatusTooManyRequests,
+				Message: "too many requests",
+				Errors:  []string{ErrTooManyRequests.Error()},
+			})
+			return
+		default:
+			logrus.Error("[error while using submit inquiry service] ", err)
+			c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+			return
+		}
+	}
+
+	c.JSON(http.StatusCreated, &SubmitInquiryAPIResponse{
+		Status:  http.StatusCreated,
+		Message: "success",
+	})
+}
+
+func (h *Handler) GetAllInquiry(c *gin.Context) {
+	// Input Validation
+	q, errorList := listquery.Parse(c.Request.URL.Query(), listConfig)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ListInquiryAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.List(q)
+	if err != nil {
+		logrus.Error("[error while using list inquiry service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &ListInquiryAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) GetInquiryByID(c *gin.Context) {
+	inquiryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, GetInquiryByIDAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  []string{"id should be a number"},
+		})
+		return
+	}
+
+	res, err := h.service.Get(inquiryID)
+	if err != nil {
+		if errors.Cause(err) == ErrInquiryNotFound {
+			c.JSON(http.StatusNotFound, GetInquiryByIDAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrInquiryNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using get inquiry service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, GetInquiryByIDAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) UpdateInquiryStatus(c *gin.Context) {
+	var (
+		errorList   []string
+		requestBody UpdateStatusAPIRequest
+	)
+
+	// Input Validation
+	inquiryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	err = c.ShouldBindJSON(&requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	if requestBody.Status != StatusNew && requestBody.Status != StatusRead && requestBody.Status != StatusArchived {
+		errorList = append(errorList, "status should be one of new, read or archived")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &UpdateStatusAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.UpdateStatus(inquiryID, requestBody.Status)
+	if err != nil {
+		if errors.Cause(err) == ErrInquiryNotFound {
+			c.JSON(http.StatusNotFound, &UpdateStatusAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrInquiryNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using update inquiry status service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &UpdateStatusAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) AddNote(c *gin.Context) {
+	var (
+		errorList   []string
+		requestBody AddNoteAPIRequest
+	)
+
+	payload, err := utils.GetPayloadFromContext(c)
+	if err != nil {
+		logrus.Error("[error while extracting context] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	// Input Validation
+	inquiryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	err = c.ShouldBindJSON(&requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	if strings.TrimSpace(requestBody.Note) == "" {
+		errorList = append(errorList, "note is required")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &AddNoteAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.AddNote(inquiryID, payload.UserID, requestBody.Note)
+	if err != nil {
+		if errors.Cause(err) == ErrInquiryNotFound {
+			c.JSON(http.StatusNotFound, &AddNoteAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrInquiryNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using add inquiry note service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusCreated, &AddNoteAPIResponse{
+		Status:  http.StatusCreated,
+		Message: "success",
+		Data:    res,
+	})
+}
@@ -0,0 +1,159 @@
+package inquiry
+
+import (
+	"context"
+	"database/sql"
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/ratelimit"
+	"time"
+)
+
+const (
+	// foreignKeyViolation is the PostgreSQL error code for foreign_key_violation
+	foreignKeyViolation = "23503"
+
+	inquiryColumns = "id, name, email, company, subject, message, status, ip_address, user_agent, created_at, read_at"
+
+	rateLimitKeyPrefix = "inquiry_rate_limit:"
+)
+
+// NewRepo PostgreSQL for inquiries and Redis for rate limiting
+func NewRepo(db *sqlx.DB, rdb *redis.Client) Repo {
+	return &repo{
+		db:  db,
+		rdb: rdb,
+	}
+}
+
+type Repo interface {
+	CountSubmission(ipAddress string, window time.Duration, ctx context.Context) (int64, error)
+	Create(submission Submission) (*Inquiry, error)
+	List(q *listquery.Query) (*ListInquiry, error)
+	GetByID(ID int) (*Inquiry, error)
+	ListNotes(inquiryID int) ([]Note, error)
+	UpdateStatus(ID int, status string) (*Inquiry, error)
+	AddNote(inquiryID, userID int, note string) (*Note, error)
+}
+
+type repo struct {
+	db  *sqlx.DB
+	rdb *redis.Client
+}
+
+// CountSubmission counts a submission from ipAddress and returns how many were made in the current window
+func (r repo) CountSubmission(ipAddress string, window time.Duration, ctx context.Context) (int64, error) {
+	count, err := ratelimit.Count(r.rdb, rateLimitKeyPrefix+ipAddress, window, ctx)
+	if err != nil {
+		return 0, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return count, nil
+}
+
+func (r repo) Create(submission Submission) (*Inquiry, error) {
+	var inquiry Inquiry
+	err := r.db.Get(&inquiry, `
+		INSERT INTO inquiries (name, email, company, subject, message, ip_address, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+inquiryColumns,
+		submission.Name, submission.Email, submission.Company, submission.Subject, submission.Message, submission.IPAddress, submission.UserAgent)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &inquiry, nil
+}
+
+func (r repo) List(q *listquery.Query) (*ListInquiry, error) {
+	inquiries := ListInquiry{Inquiries: []Inquiry{}}
+
+	query, args := q.SelectSQL(inquiryColumns, "inquiries", "")
+	err := r.db.Select(&inquiries.Inquiries, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	inquiries.Page, err = q.Paginate(&inquiries.Inquiries)
+	if err != nil {
+		return nil, err
+	}
+
+	inquiries.Total, err = q.Total(r.db, "inquiries", "")
+	if err != nil {
+		return nil, err
+	}
+
+	inquiries.Count = len(inquiries.Inquiries)
+
+	return &inquiries, nil
+}
+
+func (r repo) GetByID(ID int) (*Inquiry, error) {
+	var inquiry Inquiry
+	err := r.db.Get(&inquiry, "SELECT "+inquiryColumns+" FROM inquiries WHERE id=$1", ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrInquiryNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &inquiry, nil
+}
+
+func (r repo) ListNotes(inquiryID int) ([]Note, error) {
+	notes := []Note{}
+	err := r.db.Select(&notes, `
+		SELECT n.id, n.user_id, u.username, n.note, n.created_at
+		FROM inquiry_notes n
+		LEFT JOIN users u ON u.id = n.user_id
+		WHERE n.inquiry_id=$1
+		ORDER BY n.created_at, n.id`, inquiryID)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return notes, nil
+}
+
+// UpdateStatus keeps the first time an inquiry was read, unless it is marked as new again
+func (r repo) UpdateStatus(ID int, status string) (*Inquiry, error) {
+	var inquiry Inquiry
+	err := r.db.Get(&inquiry, `
+		UPDATE inquiries
+		SET status=$1, read_at=CASE WHEN $1 = 'new' THEN NULL ELSE COALESCE(read_at, NOW()) END
+		WHERE id=$2
+		RETURNING `+inquiryColumns, status, ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrInquiryNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &inquiry, nil
+}
+
+func (r repo) AddNote(inquiryID, userID int, note string) (*Note, error) {
+	var n Note
+	err := r.db.Get(&n, `
+		WITH inserted AS (
+			INSERT INTO inquiry_notes (inquiry_id, user_id, note) VALUES ($1, $2, $3)
+			RETURNING id, user_id, note, created_at
+		)
+		SELECT i.id, i.user_id, u.username, i.note, i.created_at
+		FROM inserted i
+		LEFT JOIN users u ON u.id = i.user_id`, inquiryID, userID, note)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == foreignKeyViolation && pqErr.Constraint == "inquiry_notes_inquiry_id_fkey" {
+			return nil, errors.Wrap(ErrInquiryNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &n, nil
+}
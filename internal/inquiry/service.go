@@ -0,0 +1,154 @@
+package inquiry
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config tunes the spam protection of the contact form
+type Config struct {
+	// MinFillTime is how long a human needs at least to fill the form in
+	MinFillTime time.Duration
+	// MaxFormAge is how long a form token stays valid
+	MaxFormAge time.Duration
+	// RateLimit is how many inquiries an IP address may submit per RateWindow
+	RateLimit  int64
+	RateWindow time.Duration
+}
+
+func NewService(repo Repo, secretKey string, config Config) Service {
+	return &service{
+		repo:      repo,
+		secretKey: secretKey,
+		config:    config,
+	}
+}
+
+type Service interface {
+	FormToken() string
+	Submit(submission Submission, honeypot, formToken string, ctx context.Context) (*Inquiry, error)
+	List(q *listquery.Query) (*ListInquiry, error)
+	Get(ID int) (*Detail, error)
+	UpdateStatus(ID int, status string) (*Inquiry, error)
+	AddNote(inquiryID, userID int, note string) (*Note, error)
+}
+
+type service struct {
+	repo      Repo
+	secretKey string
+	config    Config
+}
+
+// FormToken signs the time the form was rendered, so Submit can tell how long it took to fill in
+func (s service) FormToken() string {
+	issuedAt := strconv.FormatInt(time.Now().UnixNano(), 10)
+	return issuedAt + "." + s.sign(issuedAt)
+}
+
+// Submit stores an inquiry after running it through the honeypot, fill time and rate limit checks
+func (s service) Submit(submission Submission, honeypot, formToken string, ctx context.Context) (*Inquiry, error) {
+	if honeypot != "" {
+		return nil, errors.Wrap(ErrSpamDetected, "honeypot field is filled in")
+	}
+
+	issuedAt, err := s.verifyFormToken(formToken)
+	if err != nil {
+		return nil, err
+	}
+
+	elapsed := time.Since(issuedAt)
+	if elapsed > s.config.MaxFormAge {
+		return nil, errors.Wrap(ErrInvalidFormToken, "form token has expired")
+	}
+
+	if elapsed < s.config.MinFillTime {
+		return nil, errors.Wrapf(ErrSubmittedTooFast, "form filled in %s", elapsed)
+	}
+
+	count, err := s.repo.CountSubmission(submission.IPAddress, s.config.RateWindow, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > s.config.RateLimit {
+		return nil, errors.Wrapf(ErrTooManyRequests, "%d inquiries from %s", count, submission.IPAddress)
+	}
+
+	inquiry, err := s.repo.Create(submission)
+	if err != nil {
+		return nil, err
+	}
+
+	return inquiry, nil
+}
+
+func (s service) List(q *listquery.Query) (*ListInquiry, error) {
+	inquiries, err := s.repo.List(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return inquiries, nil
+}
+
+func (s service) Get(ID int) (*Detail, error) {
+	inquiry, err := s.repo.GetByID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := s.repo.ListNotes(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Detail{
+		Inquiry: *inquiry,
+		Notes:   notes,
+	}, nil
+}
+
+func (s service) UpdateStatus(ID int, status string) (*Inquiry, error) {
+	inquiry, err := s.repo.UpdateStatus(ID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return inquiry, nil
+}
+
+func (s service) AddNote(inquiryID, userID int, note string) (*Note, error) {
+	n, err := s.repo.AddNote(inquiryID, userID, note)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+func (s service) sign(value string) string {
+	mac := hmac.New(sha256.New, []byte(s.secretKey))
+	mac.Write([]byte("inquiry-form:" + value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s service) verifyFormToken(formToken string) (time.Time, error) {
+	parts := strings.SplitN(formToken, ".", 2)
+	if len(parts) != 2 || !hmac.Equal([]byte(parts[1]), []byte(s.sign(parts[0]))) {
+		return time.Time{}, errors.Wrap(ErrInvalidFormToken, "form token signature mismatch")
+	}
+
+	issuedAt, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Wrap(ErrInvalidFormToken, err.Error())
+	}
+
+	return time.Unix(0, issuedAt), nil
+}
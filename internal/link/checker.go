@@ -0,0 +1,179 @@
+package link
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// reservedNetworks aren't reachable on the internet, or only through a gateway of our own.
+// Loopback, private and link-local addresses are ruled out by net.IP itself.
+var reservedNetworks = parseNetworks(
+	"0.0.0.0/8",       // "this" network
+	"100.64.0.0/10",   // carrier-grade NAT
+	"192.0.0.0/24",    // IETF protocol assignments
+	"192.0.2.0/24",    // documentation
+	"198.18.0.0/15",   // benchmarking
+	"198.51.100.0/24", // documentation
+	"203.0.113.0/24",  // documentation
+	"240.0.0.0/4",     // reserved, broadcast included
+	"64:ff9b::/96",    // NAT64, reaches IPv4 addresses through a local translator
+	"64:ff9b:1::/48",  // local-use NAT64
+	"100::/64",        // discard
+	"2001::/23",       // IETF protocol assignments
+	"2001:db8::/32",   // documentation
+	"2002::/16",       // 6to4, embeds an IPv4 address
+)
+
+// transport is shared by every check. Links are entered by editors, so the checker only ever connects
+// to public addresses, which it makes sure of after DNS resolution and again for every redirect.
+// Environment proxies are ignored, they would do the connecting in its place.
+var transport = &http.Transport{
+	DialContext: (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   publicOnly,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          100,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: 1 * time.Second,
+}
+
+// StartChecker checks the links that are due every interval until the process exits
+func StartChecker(service Service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		for range ticker.C {
+			checked, err := service.CheckDue(context.Background())
+			if err != nil {
+				logrus.Error("[error while checking links] ", err)
+			}
+
+			if checked != 0 {
+				logrus.Infof("Checked %d link(s)", checked)
+			}
+		}
+	}()
+}
+
+// check asks for url with a HEAD request. Plenty of servers answer HEAD wrong while the page
+// is fine, so an unhealthy answer is double checked with a GET.
+func check(url, userAgent string, timeout time.Duration, ctx context.Context) Result {
+	result := request(http.MethodHead, url, userAgent, timeout, ctx)
+	if !result.OK && result.StatusCode != 0 {
+		result = request(http.MethodGet, url, userAgent, timeout, ctx)
+	}
+
+	return result
+}
+
+func request(method, url, userAgent string, timeout time.Duration, ctx context.Context) Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := Result{Redirects: []Hop{}}
+	client := &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			result.Redirects = append(result.Redirects, Hop{
+				URL:        via[len(via)-1].URL.String(),
+				StatusCode: req.Response.StatusCode,
+			})
+			if len(via) > maxRedirects {
+				return errors.New("stopped after too many redirects")
+			}
+			return nil
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	res, err := client.Do(req)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer res.Body.Close()
+
+	// Drain a little of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxBodySize))
+
+	result.StatusCode = res.StatusCode
+	result.FinalURL = res.Request.URL.String()
+	result.OK = healthy(res.StatusCode)
+	if !result.OK {
+		result.Error = res.Status
+	}
+
+	return result
+}
+
+// healthy tells whether a link answering with code works. Servers asking for a login or
+// turning bots away are up, they just won't show the checker the page.
+func healthy(code int) bool {
+	switch {
+	case code >= 200 && code < 400:
+		return true
+	case code == http.StatusUnauthorized, code == http.StatusForbidden, code == http.StatusTooManyRequests:
+		return true
+	default:
+		return false
+	}
+}
+
+// publicOnly refuses to connect to an address that isn't public, it's called with the resolved address
+func publicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublic(ip) {
+		return fmt.Errorf("connecting to %s is not allowed, it isn't a public address", host)
+	}
+
+	return nil
+}
+
+func isPublic(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+
+	return networks
+}
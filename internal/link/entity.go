@@ -0,0 +1,147 @@
+package link
+
+import (
+	"github.com/jmoiron/sqlx/types"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"time"
+)
+
+// Link types, what a link points to
+const (
+	TypeDemo       = "demo"
+	TypeRepository = "repository"
+	TypeAppStore   = "app_store"
+	TypePlayStore  = "play_store"
+	TypeWebsite    = "website"
+	TypeOther      = "other"
+)
+
+var Types = []string{TypeDemo, TypeRepository, TypeAppStore, TypePlayStore, TypeWebsite, TypeOther}
+
+// Link health. A failing link only turns broken after failing Config.BrokenAfter checks in a row,
+// so a site that is down for a moment doesn't get flagged.
+const (
+	HealthUnchecked = "unchecked"
+	HealthOK        = "ok"
+	HealthFailing   = "failing"
+	HealthBroken    = "broken"
+)
+
+const (
+	MaxLabelLength = 128
+	MaxURLLength   = 2048
+
+	// maxRedirects is how many redirects the checker follows before giving up on a link
+	maxRedirects = 10
+
+	// maxBodySize is how much of a GET response the checker reads before hanging up
+	maxBodySize = 64 << 10
+)
+
+// Link entity represent project_links table in database
+type Link struct {
+	ID          int            `json:"id" db:"id"`
+	ProjectID   int            `json:"project_id" db:"project_id"`
+	ProjectName string         `json:"project_name" db:"project_name"`
+	Type        string         `json:"type" db:"type"`
+	Label       *string        `json:"label" db:"label"`
+	URL         string         `json:"url" db:"url"`
+	Health      string         `json:"health" db:"health"`
+	StatusCode  *int           `json:"status_code" db:"status_code"`
+	FinalURL    *string        `json:"final_url" db:"final_url"`
+	Redirects   types.JSONText `json:"redirects" db:"redirects"`
+	CheckError  *string        `json:"check_error" db:"check_error"`
+	Failures    int            `json:"failures" db:"failures"`
+	CheckedAt   *time.Time     `json:"checked_at" db:"checked_at"`
+	BrokenSince *time.Time     `json:"broken_since" db:"broken_since"`
+	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
+}
+
+// Hop is a redirect the checker followed, from URL with StatusCode
+type Hop struct {
+	URL        string `json:"url"`
+	StatusCode int    `json:"status_code"`
+}
+
+// Result of checking a link. StatusCode is 0 when the server never answered.
+type Result struct {
+	OK         bool
+	StatusCode int
+	FinalURL   string
+	Redirects  []Hop
+	Error      string
+}
+
+// Config of the link checker
+type Config struct {
+	// RecheckAfter is how old the last check of a link gets before it's checked again
+	RecheckAfter time.Duration
+	Timeout      time.Duration
+	Concurrency  int
+	BatchSize    int
+	BrokenAfter  int
+	UserAgent    string
+}
+
+// Report counts links by health and lists the broken ones, longest broken first
+type Report struct {
+	Unchecked int    `json:"unchecked" db:"unchecked"`
+	OK        int    `json:"ok" db:"ok"`
+	Failing   int    `json:"failing" db:"failing"`
+	Broken    int    `json:"broken" db:"broken"`
+	Links     []Link `json:"broken_links" db:"-"`
+}
+
+type ListLink struct {
+	Links []Link `json:"links"`
+	Count int    `json:"count"`
+	listquery.Page
+}
+
+// listConfig whitelists sort and filter parameters for List
+var listConfig = listquery.Config{
+	Fields: map[string]listquery.Field{
+		"id":         {Column: "id", Type: listquery.TypeInt, Sortable: true},
+		"project_id": {Column: "project_id", Type: listquery.TypeInt, Filterable: true},
+		"type":       {Column: "type", Filterable: true},
+		"health":     {Column: "health", Filterable: true},
+		"failures":   {Column: "failures", Type: listquery.TypeInt, Sortable: true, Filterable: true},
+		// checked_at is NULL until the first check, which keyset paging can't sort on
+		"checked_at": {Column: "checked_at", Type: listquery.TypeTime, Filterable: true},
+	},
+	IDField:      "id",
+	DefaultSort:  "id",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// ListLinkAPIResponse API response for List
+type ListLinkAPIResponse struct {
+	Status  int       `json:"status"`
+	Message string    `json:"message"`
+	Data    *ListLink `json:"data,omitempty"`
+	Errors  []string  `json:"errors,omitempty"`
+}
+
+// LinkAPIRequest create and update link request body from client
+type LinkAPIRequest struct {
+	Type  string  `json:"type"`
+	Label *string `json:"label"`
+	URL   string  `json:"url"`
+}
+
+// LinkAPIResponse API response for Create, Update, Delete and Check
+type LinkAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Link    `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// ReportAPIResponse API response for Report
+type ReportAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Report  `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
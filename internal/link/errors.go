@@ -0,0 +1,10 @@
+package link
+
+import "github.com/pkg/errors"
+
+var (
+	ErrLinkNotFound     = errors.New("link not found")
+	ErrProjectNotFound  = errors.New("project not found")
+	ErrLinkAlreadyExist = errors.New("project already has a link to this url")
+	ErrInternalServer   = errors.New("internal server error")
+)
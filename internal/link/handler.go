@@ -0,0 +1,244 @@
+package link
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) GetAllLink(c *gin.Context) {
+	// Input Validation
+	q, errorList := listquery.Parse(c.Request.URL.Query(), listConfig)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ListLinkAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.List(q)
+	if err != nil {
+		logrus.Error("[error while using list link service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &ListLinkAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+// GetLinkReport reports the health of every link and lists the broken ones
+func (h *Handler) GetLinkReport(c *gin.Context) {
+	res, err := h.service.Report()
+	if err != nil {
+		logrus.Error("[error while using link report service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &ReportAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) CreateLink(c *gin.Context) {
+	var (
+		errorList   []string
+		requestBody LinkAPIRequest
+	)
+
+	// Input Validation
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	err = c.ShouldBindJSON(&requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	errorList = append(errorList, validate(&requestBody)...)
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &LinkAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Create(projectID, requestBody.Type, requestBody.Label, requestBody.URL)
+	if err != nil {
+		h.respondError(c, err, "[error while using create link service] ")
+		return
+	}
+
+	c.JSON(http.StatusCreated, &LinkAPIResponse{
+		Status:  http.StatusCreated,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) UpdateLink(c *gin.Context) {
+	var (
+		errorList   []string
+		requestBody LinkAPIRequest
+	)
+
+	// Input Validation
+	linkID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	err = c.ShouldBindJSON(&requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	errorList = append(errorList, validate(&requestBody)...)
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &LinkAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Update(linkID, requestBody.Type, requestBody.Label, requestBody.URL)
+	if err != nil {
+		h.respondError(c, err, "[error while using update link service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &LinkAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) DeleteLink(c *gin.Context) {
+	linkID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &LinkAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  []string{"id should be a number"},
+		})
+		return
+	}
+
+	err = h.service.Delete(linkID)
+	if err != nil {
+		h.respondError(c, err, "[error while using delete link service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &LinkAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+	})
+}
+
+// CheckLink checks a link right away and returns it with the result
+func (h *Handler) CheckLink(c *gin.Context) {
+	linkID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &LinkAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  []string{"id should be a number"},
+		})
+		return
+	}
+
+	res, err := h.service.Check(linkID, c)
+	if err != nil {
+		h.respondError(c, err, "[error while using check link service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &LinkAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) respondError(c *gin.Context, err error, logPrefix string) {
+	switch errors.Cause(err) {
+	case ErrLinkNotFound, ErrProjectNotFound:
+		c.JSON(http.StatusNotFound, &LinkAPIResponse{
+			Status:  http.StatusNotFound,
+			Message: "not found",
+			Errors:  []string{errors.Cause(err).Error()},
+		})
+	case ErrLinkAlreadyExist:
+		c.JSON(http.StatusBadRequest, &LinkAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  []string{ErrLinkAlreadyExist.Error()},
+		})
+	default:
+		logrus.Error(logPrefix, err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+	}
+}
+
+// validate trims the link in requestBody and lists what's wrong with it
+func validate(requestBody *LinkAPIRequest) []string {
+	var errorList []string
+
+	requestBody.URL = strings.TrimSpace(requestBody.URL)
+	if requestBody.Label != nil {
+		label := strings.TrimSpace(*requestBody.Label)
+		requestBody.Label = &label
+		if label == "" {
+			requestBody.Label = nil
+		}
+	}
+
+	if !IsType(requestBody.Type) {
+		errorList = append(errorList, "type should be one of "+strings.Join(Types, ", "))
+	}
+
+	if requestBody.Label != nil && len(*requestBody.Label) > MaxLabelLength {
+		errorList = append(errorList, "label should be less than 128 characters")
+	}
+
+	if requestBody.URL == "" {
+		errorList = append(errorList, "url is required")
+	} else if !IsValidURL(requestBody.URL) {
+		errorList = append(errorList, "url should be an absolute http or https url of less than 2048 characters")
+	}
+
+	return errorList
+}
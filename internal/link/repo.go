@@ -0,0 +1,234 @@
+package link
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"time"
+)
+
+const (
+	// uniqueViolation is the PostgreSQL error code for unique_violation
+	uniqueViolation = "23505"
+
+	linkColumns = "id, project_id, project_name, type, label, url, health, status_code, final_url, redirects, check_error, failures, checked_at, broken_since, created_at"
+
+	// linksFrom joins the project name in and hides links of trashed projects
+	linksFrom = `(
+		SELECT l.*, p.name AS project_name
+		FROM project_links l
+		JOIN projects p ON p.id = l.project_id AND p.deleted_at IS NULL
+	) links_view`
+)
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+type Repo interface {
+	List(q *listquery.Query) (*ListLink, error)
+	GetByID(ID int) (*Link, error)
+	Create(projectID int, linkType string, label *string, url string) (*Link, error)
+	Update(ID int, linkType string, label *string, url string) (*Link, error)
+	Delete(ID int) error
+	Report() (*Report, error)
+	ListDue(checkedBefore time.Time, limit int) ([]Link, error)
+	SaveResult(ID int, url string, result Result, brokenAfter int) error
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func (r repo) List(q *listquery.Query) (*ListLink, error) {
+	links := ListLink{Links: []Link{}}
+
+	query, args := q.SelectSQL(linkColumns, linksFrom, "")
+	err := r.db.Select(&links.Links, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	links.Page, err = q.Paginate(&links.Links)
+	if err != nil {
+		return nil, err
+	}
+
+	links.Total, err = q.Total(r.db, linksFrom, "")
+	if err != nil {
+		return nil, err
+	}
+
+	links.Count = len(links.Links)
+
+	return &links, nil
+}
+
+func (r repo) GetByID(ID int) (*Link, error) {
+	var link Link
+	err := r.db.Get(&link, "SELECT "+linkColumns+" FROM "+linksFrom+" WHERE id=$1", ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrLinkNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &link, nil
+}
+
+// Create adds a link to a project, trashed projects can't get new links
+func (r repo) Create(projectID int, linkType string, label *string, url string) (*Link, error) {
+	var ID int
+	err := r.db.Get(&ID, `
+		INSERT INTO project_links (project_id, type, label, url)
+		SELECT id, $2, $3, $4 FROM projects WHERE id=$1 AND deleted_at IS NULL
+		RETURNING id`, projectID, linkType, label, url)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrProjectNotFound, err.Error())
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+			return nil, errors.Wrap(ErrLinkAlreadyExist, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return r.GetByID(ID)
+}
+
+// Update changes a link, a link pointing somewhere else starts over as unchecked
+func (r repo) Update(ID int, linkType string, label *string, url string) (*Link, error) {
+	res, err := r.db.Exec(`
+		UPDATE project_links SET type=$1, label=$2, url=$3,
+			health = CASE WHEN url = $3 THEN health ELSE 'unchecked' END,
+			status_code = CASE WHEN url = $3 THEN status_code END,
+			final_url = CASE WHEN url = $3 THEN final_url END,
+			redirects = CASE WHEN url = $3 THEN redirects ELSE '[]' END,
+			check_error = CASE WHEN url = $3 THEN check_error END,
+			failures = CASE WHEN url = $3 THEN failures ELSE 0 END,
+			checked_at = CASE WHEN url = $3 THEN checked_at END,
+			broken_since = CASE WHEN url = $3 THEN broken_since END
+		WHERE id=$4 AND project_id IN (SELECT id FROM projects WHERE deleted_at IS NULL)`,
+		linkType, label, url, ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+			return nil, errors.Wrap(ErrLinkAlreadyExist, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	if count == 0 {
+		return nil, errors.Wrap(ErrLinkNotFound, "no link updated")
+	}
+
+	return r.GetByID(ID)
+}
+
+func (r repo) Delete(ID int) error {
+	res, err := r.db.Exec("DELETE FROM project_links WHERE id=$1", ID)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	if count == 0 {
+		return errors.Wrap(ErrLinkNotFound, "no link deleted")
+	}
+
+	return nil
+}
+
+func (r repo) Report() (*Report, error) {
+	report := Report{Links: []Link{}}
+	err := r.db.Get(&report, `
+		SELECT
+			COUNT(*) FILTER (WHERE health = 'unchecked') AS unchecked,
+			COUNT(*) FILTER (WHERE health = 'ok') AS ok,
+			COUNT(*) FILTER (WHERE health = 'failing') AS failing,
+			COUNT(*) FILTER (WHERE health = 'broken') AS broken
+		FROM `+linksFrom)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = r.db.Select(&report.Links, "SELECT "+linkColumns+" FROM "+linksFrom+" WHERE health = 'broken' ORDER BY broken_since, id")
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &report, nil
+}
+
+// ListDue lists links that were never checked or were last checked before checkedBefore, least recently checked first
+func (r repo) ListDue(checkedBefore time.Time, limit int) ([]Link, error) {
+	links := []Link{}
+	err := r.db.Select(&links, `
+		SELECT `+linkColumns+` FROM `+linksFrom+`
+		WHERE checked_at IS NULL OR checked_at < $1
+		ORDER BY checked_at NULLS FIRST, id
+		LIMIT $2`, checkedBefore, limit)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return links, nil
+}
+
+// SaveResult records a check of url. A link that failed brokenAfter checks in a row turns broken,
+// one good check makes it ok again. Links edited to point somewhere else while they were being checked are left alone.
+func (r repo) SaveResult(ID int, url string, result Result, brokenAfter int) error {
+	redirects, err := json.Marshal(result.Redirects)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	var statusCode *int
+	if result.StatusCode != 0 {
+		statusCode = &result.StatusCode
+	}
+
+	var finalURL, checkError *string
+	if result.FinalURL != "" {
+		finalURL = &result.FinalURL
+	}
+	if result.Error != "" {
+		checkError = &result.Error
+	}
+
+	_, err = r.db.Exec(`
+		UPDATE project_links SET status_code=$3, final_url=$4, redirects=$5, check_error=$6, checked_at=NOW(),
+			failures = CASE WHEN $7::boolean THEN 0 ELSE failures + 1 END,
+			health = CASE
+				WHEN $7::boolean THEN 'ok'
+				WHEN failures + 1 >= $8::integer THEN 'broken'
+				ELSE 'failing'
+			END,
+			broken_since = CASE
+				WHEN $7::boolean THEN NULL
+				WHEN failures + 1 >= $8::integer THEN COALESCE(broken_since, NOW())
+				ELSE broken_since
+			END
+		WHERE id=$1 AND url=$2`,
+		ID, url, statusCode, finalURL, string(redirects), checkError, result.OK, brokenAfter)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
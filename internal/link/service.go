@@ -0,0 +1,105 @@
+package link
+
+import (
+	"context"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"sync"
+	"time"
+)
+
+func NewService(repo Repo, config Config) Service {
+	return &service{
+		repo:   repo,
+		config: config,
+	}
+}
+
+type Service interface {
+	List(q *listquery.Query) (*ListLink, error)
+	Report() (*Report, error)
+	Create(projectID int, linkType string, label *string, url string) (*Link, error)
+	Update(ID int, linkType string, label *string, url string) (*Link, error)
+	Delete(ID int) error
+	Check(ID int, ctx context.Context) (*Link, error)
+	CheckDue(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo   Repo
+	config Config
+}
+
+func (s service) List(q *listquery.Query) (*ListLink, error) {
+	return s.repo.List(q)
+}
+
+func (s service) Report() (*Report, error) {
+	return s.repo.Report()
+}
+
+func (s service) Create(projectID int, linkType string, label *string, url string) (*Link, error) {
+	return s.repo.Create(projectID, linkType, label, url)
+}
+
+func (s service) Update(ID int, linkType string, label *string, url string) (*Link, error) {
+	return s.repo.Update(ID, linkType, label, url)
+}
+
+func (s service) Delete(ID int) error {
+	return s.repo.Delete(ID)
+}
+
+// Check checks a link right away instead of waiting for the checker to get to it
+func (s service) Check(ID int, ctx context.Context) (*Link, error) {
+	link, err := s.repo.GetByID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.SaveResult(link.ID, link.URL, check(link.URL, s.config.UserAgent, s.config.Timeout, ctx), s.config.BrokenAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ID)
+}
+
+// CheckDue checks a batch of the links due for a check, a few at a time, and returns how many were checked
+func (s service) CheckDue(ctx context.Context) (int, error) {
+	links, err := s.repo.ListDue(time.Now().Add(-s.config.RecheckAfter), s.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		checked int
+		saveErr error
+	)
+	slots := make(chan struct{}, s.config.Concurrency)
+	for _, link := range links {
+		wg.Add(1)
+		slots <- struct{}{}
+
+		go func(link Link) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			err := s.repo.SaveResult(link.ID, link.URL, check(link.URL, s.config.UserAgent, s.config.Timeout, ctx), s.config.BrokenAfter)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				saveErr = err
+				return
+			}
+			checked++
+		}(link)
+	}
+	wg.Wait()
+
+	return checked, saveErr
+}
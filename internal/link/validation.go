@@ -0,0 +1,28 @@
+package link
+
+import "net/url"
+
+// IsType tells whether t is one of the link types
+func IsType(t string) bool {
+	for _, linkType := range Types {
+		if linkType == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsValidURL tells whether rawURL is an absolute http or https URL the checker can reach
+func IsValidURL(rawURL string) bool {
+	if len(rawURL) > MaxURLLength {
+		return false
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
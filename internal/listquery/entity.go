@@ -0,0 +1,52 @@
+package listquery
+
+// FieldType is the kind of value a column holds, filter values and cursors are checked against it
+type FieldType int
+
+const (
+	TypeString FieldType = iota
+	TypeInt
+	TypeBool
+	// TypeTime values are RFC 3339 timestamps
+	TypeTime
+)
+
+// Field is a column that a list endpoint exposes for sorting and filtering.
+// Sortable columns must be NOT NULL since they are used in the keyset cursor.
+type Field struct {
+	Column     string
+	Type       FieldType
+	Sortable   bool
+	Filterable bool
+}
+
+// Config whitelists what a list endpoint accepts. Fields are keyed by their API name.
+// Sortable fields and IDField must be named after the db tag of the row struct
+// so the cursor can be read back from the last row.
+type Config struct {
+	Fields       map[string]Field
+	IDField      string
+	DefaultSort  string
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// Filter is a single filter[field][operator]=value query parameter
+type Filter struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+// Page is the pagination metadata returned along with a list
+type Page struct {
+	NextCursor *string `json:"next_cursor"`
+	Total      *int    `json:"total,omitempty"`
+}
+
+// cursor is the decoded form of the opaque cursor query parameter
+type cursor struct {
+	Sort  string      `json:"s"`
+	Value interface{} `json:"v"`
+	ID    interface{} `json:"id"`
+}
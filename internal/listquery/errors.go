@@ -0,0 +1,8 @@
+package listquery
+
+import "github.com/pkg/errors"
+
+var (
+	ErrInvalidCursor  = errors.New("cursor is invalid")
+	ErrInternalServer = errors.New("internal server error")
+)
@@ -0,0 +1,343 @@
+package listquery
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// operators maps filter operators from query parameter to SQL
+var operators = map[string]string{
+	"eq":   "=",
+	"ne":   "<>",
+	"lt":   "<",
+	"lte":  "<=",
+	"gt":   ">",
+	"gte":  ">=",
+	"like": "ILIKE",
+	"in":   "= ANY",
+}
+
+var (
+	filterParam = regexp.MustCompile(`^filter\[([a-z_]+)\](?:\[([a-z]+)\])?$`)
+	likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	mapper      = reflectx.NewMapperFunc("db", sqlx.NameMapper)
+)
+
+// Query is a parsed and validated list request
+type Query struct {
+	config       Config
+	Limit        int
+	Sort         string
+	Desc         bool
+	Filters      []Filter
+	IncludeTotal bool
+	cursor       *cursor
+}
+
+// Parse reads limit, cursor, sort, filter[...] and include_total from query parameters.
+// Anything not whitelisted in config is reported in the returned error list.
+func Parse(values url.Values, config Config) (*Query, []string) {
+	var errorList []string
+
+	q := &Query{
+		config: config,
+		Limit:  config.DefaultLimit,
+	}
+
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > config.MaxLimit {
+			errorList = append(errorList, fmt.Sprintf("limit should be a number between 1 and %d", config.MaxLimit))
+		}
+		q.Limit = n
+	}
+
+	sort := values.Get("sort")
+	if sort == "" {
+		sort = config.DefaultSort
+	}
+	q.Sort = strings.TrimPrefix(sort, "-")
+	q.Desc = strings.HasPrefix(sort, "-")
+	if field, ok := config.Fields[q.Sort]; !ok || !field.Sortable {
+		errorList = append(errorList, fmt.Sprintf("sorting by %s is not allowed", q.Sort))
+	}
+
+	for key, value := range values {
+		matches := filterParam.FindStringSubmatch(key)
+		if matches == nil {
+			continue
+		}
+
+		operator := matches[2]
+		if operator == "" {
+			operator = "eq"
+		}
+
+		field, ok := config.Fields[matches[1]]
+		if !ok || !field.Filterable {
+			errorList = append(errorList, fmt.Sprintf("filtering by %s is not allowed", matches[1]))
+			continue
+		}
+
+		if _, ok := operators[operator]; !ok {
+			errorList = append(errorList, fmt.Sprintf("filter operator %s is not supported", operator))
+			continue
+		}
+
+		if operator == "like" && field.Type != TypeString {
+			errorList = append(errorList, fmt.Sprintf("filter operator like is not supported on %s", matches[1]))
+			continue
+		}
+
+		filterValue, err := parseFilterValue(field.Type, operator, value[0])
+		if err != nil {
+			errorList = append(errorList, fmt.Sprintf("filter on %s %s", matches[1], err))
+			continue
+		}
+
+		q.Filters = append(q.Filters, Filter{Field: matches[1], Operator: operator, Value: filterValue})
+	}
+
+	q.IncludeTotal, _ = strconv.ParseBool(values.Get("include_total"))
+
+	if encoded := values.Get("cursor"); encoded != "" {
+		c, err := decodeCursor(encoded)
+		if err != nil || c.Sort != sort || !c.matches(config.Fields[q.Sort].Type, config.Fields[config.IDField].Type) {
+			errorList = append(errorList, ErrInvalidCursor.Error())
+		}
+		q.cursor = c
+	}
+
+	return q, errorList
+}
+
+// SelectSQL builds a filtered, sorted and cursor-paginated query. where and args are the
+// caller's own conditions, and must use placeholders starting at $1.
+// One row more than Limit is fetched so Paginate knows whether there is a next page.
+func (q *Query) SelectSQL(columns, from, where string, args ...interface{}) (string, []interface{}) {
+	conditions, args := q.conditions(where, args)
+
+	sortColumn := q.config.Fields[q.Sort].Column
+	idColumn := q.config.Fields[q.config.IDField].Column
+
+	if q.cursor != nil {
+		comparison := ">"
+		if q.Desc {
+			comparison = "<"
+		}
+		args = append(args, q.cursor.Value, q.cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(%s, %s) %s ($%d, $%d)", sortColumn, idColumn, comparison, len(args)-1, len(args)))
+	}
+
+	direction := "ASC"
+	if q.Desc {
+		direction = "DESC"
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", columns, from)
+	if len(conditions) != 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, %s %s LIMIT %d", sortColumn, direction, idColumn, direction, q.Limit+1)
+
+	return query, args
+}
+
+// CountSQL builds a query counting every row matching the filters, ignoring the cursor
+func (q *Query) CountSQL(from, where string, args ...interface{}) (string, []interface{}) {
+	conditions, args := q.conditions(where, args)
+
+	query := "SELECT COUNT(*) FROM " + from
+	if len(conditions) != 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	return query, args
+}
+
+// Paginate trims rows, a pointer to the slice fetched with SelectSQL, down to Limit
+// and builds the cursor pointing after its last row
+func (q *Query) Paginate(rows interface{}) (Page, error) {
+	var page Page
+
+	slice := reflect.ValueOf(rows).Elem()
+	if slice.Len() <= q.Limit {
+		return page, nil
+	}
+
+	slice.Set(slice.Slice(0, q.Limit))
+	last := reflect.Indirect(slice.Index(q.Limit - 1))
+
+	sortValue := mapper.FieldByName(last, q.Sort)
+	idValue := mapper.FieldByName(last, q.config.IDField)
+	if !sortValue.IsValid() || !idValue.IsValid() {
+		return page, errors.Wrap(ErrInternalServer, fmt.Sprintf("row %s has no %s or %s field", last.Type(), q.Sort, q.config.IDField))
+	}
+
+	sort := q.Sort
+	if q.Desc {
+		sort = "-" + sort
+	}
+
+	encoded, err := encodeCursor(cursor{
+		Sort:  sort,
+		Value: sortValue.Interface(),
+		ID:    idValue.Interface(),
+	})
+	if err != nil {
+		return page, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	page.NextCursor = &encoded
+	return page, nil
+}
+
+// Total counts every row matching the filters when the client asked for it with include_total
+func (q *Query) Total(db sqlx.Queryer, from, where string, args ...interface{}) (*int, error) {
+	if !q.IncludeTotal {
+		return nil, nil
+	}
+
+	var total int
+	query, args := q.CountSQL(from, where, args...)
+	err := sqlx.Get(db, &total, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &total, nil
+}
+
+func (q *Query) conditions(where string, args []interface{}) ([]string, []interface{}) {
+	var conditions []string
+	if where != "" {
+		conditions = append(conditions, "("+where+")")
+	}
+
+	for _, filter := range q.Filters {
+		column := q.config.Fields[filter.Field].Column
+		var value interface{} = filter.Value
+
+		switch filter.Operator {
+		case "like":
+			value = "%" + likeEscaper.Replace(filter.Value) + "%"
+		case "in":
+			value = pq.Array(strings.Split(filter.Value, ","))
+		}
+
+		args = append(args, value)
+		if filter.Operator == "in" {
+			conditions = append(conditions, fmt.Sprintf("%s %s($%d)", column, operators[filter.Operator], len(args)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("%s %s $%d", column, operators[filter.Operator], len(args)))
+		}
+	}
+
+	return conditions, args
+}
+
+// parseFilterValue checks value holds the type of the field filtered on, every one of them for the in operator.
+// Booleans are normalized so PostgreSQL reads them the way strconv does.
+func parseFilterValue(fieldType FieldType, operator, value string) (string, error) {
+	if fieldType == TypeString {
+		return value, nil
+	}
+
+	values := []string{value}
+	if operator == "in" {
+		values = strings.Split(value, ",")
+	}
+
+	for i, v := range values {
+		switch fieldType {
+		case TypeInt:
+			if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+				return "", errors.New("should be a whole number")
+			}
+		case TypeBool:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return "", errors.New("should be true or false")
+			}
+			values[i] = strconv.FormatBool(b)
+		case TypeTime:
+			if _, err := time.Parse(time.RFC3339, v); err != nil {
+				return "", errors.New("should be an RFC 3339 time")
+			}
+		}
+	}
+
+	return strings.Join(values, ","), nil
+}
+
+// matches tells whether the position held by the cursor has the types of the sort and id columns
+func (c *cursor) matches(sortType, idType FieldType) bool {
+	return c != nil && isType(sortType, c.Value) && isType(idType, c.ID)
+}
+
+// isType checks a value decoded from a cursor, which holds numbers as json.Number and times as strings
+func isType(fieldType FieldType, value interface{}) bool {
+	switch fieldType {
+	case TypeInt:
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := n.Int64()
+		return err == nil
+	case TypeBool:
+		_, ok := value.(bool)
+		return ok
+	case TypeTime:
+		v, ok := value.(string)
+		if !ok {
+			return false
+		}
+		_, err := time.Parse(time.RFC3339Nano, v)
+		return err == nil
+	default:
+		_, ok := value.(string)
+		return ok
+	}
+}
+
+func encodeCursor(c cursor) (string, error) {
+	content, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(content), nil
+}
+
+func decodeCursor(encoded string) (*cursor, error) {
+	content, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidCursor, err.Error())
+	}
+
+	var c cursor
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&c); err != nil {
+		return nil, errors.Wrap(ErrInvalidCursor, err.Error())
+	}
+
+	if c.Value == nil || c.ID == nil {
+		return nil, errors.Wrap(ErrInvalidCursor, "cursor is missing its position")
+	}
+
+	return &c, nil
+}
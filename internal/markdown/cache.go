@@ -0,0 +1,59 @@
+package markdown
+
+import (
+	"container/list"
+	"sync"
+)
+
+// cache keeps the most recently used renders, it's keyed by content so changed source is never served stale output
+type cache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key      string
+	rendered *Rendered
+}
+
+func newCache(size int) *cache {
+	return &cache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+func (c *cache) get(key string) (*Rendered, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return element.Value.(*cacheEntry).rendered, true
+}
+
+func (c *cache) put(key string, rendered *Rendered) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*cacheEntry).rendered = rendered
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, rendered: rendered})
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
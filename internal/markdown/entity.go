@@ -0,0 +1,10 @@
+package markdown
+
+// excerptLength is how many characters of plain text an excerpt keeps at most
+const excerptLength = 200
+
+// Rendered is Markdown source turned into sanitized HTML and a plain text excerpt
+type Rendered struct {
+	HTML    string
+	Excerpt string
+}
@@ -0,0 +1,7 @@
+package markdown
+
+import "github.com/pkg/errors"
+
+var (
+	ErrInternalServer = errors.New("internal server error")
+)
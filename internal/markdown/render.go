@@ -0,0 +1,113 @@
+package markdown
+
+import (
+	"bytes"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/pkg/errors"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+	"strings"
+	"unicode"
+)
+
+// converter leaves raw HTML out of its output, the policy strips whatever else shouldn't be there
+var converter = goldmark.New(
+	goldmark.WithExtensions(extension.Strikethrough, extension.Linkify),
+)
+
+var policy = newPolicy()
+
+// newPolicy allows headings, lists, emphasis, code, quotes and http, https or mailto links, nothing else
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements(
+		"h1", "h2", "h3", "h4", "h5", "h6",
+		"p", "br", "hr", "blockquote", "pre", "code",
+		"ul", "ol", "li", "strong", "em", "del",
+	)
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.RequireParseableURLs(true)
+	p.RequireNoFollowOnLinks(true)
+	p.RequireNoReferrerOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}
+
+func render(source string) (*Rendered, error) {
+	src := []byte(source)
+	doc := converter.Parser().Parse(text.NewReader(src))
+
+	var buf bytes.Buffer
+	err := converter.Renderer().Render(&buf, src, doc)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &Rendered{
+		HTML:    policy.Sanitize(buf.String()),
+		Excerpt: excerpt(plainText(doc, src), excerptLength),
+	}, nil
+}
+
+// plainText is the text of a document without its markup, blocks are separated by a space
+func plainText(doc ast.Node, src []byte) string {
+	var b strings.Builder
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			if n.Type() == ast.TypeBlock {
+				b.WriteByte(' ')
+			}
+			return ast.WalkContinue, nil
+		}
+
+		switch node := n.(type) {
+		case *ast.Text:
+			b.Write(node.Segment.Value(src))
+			if node.SoftLineBreak() || node.HardLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(node.Value)
+		case *ast.AutoLink:
+			b.Write(node.Label(src))
+		case *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		case *ast.FencedCodeBlock, *ast.CodeBlock:
+			lines := n.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				segment := lines.At(i)
+				b.Write(segment.Value(src))
+			}
+		case *ast.HTMLBlock:
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// excerpt cuts s down to at most length characters, at a word boundary where there is one
+func excerpt(s string, length int) string {
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+
+	// One character goes to the ellipsis
+	cut := length - 1
+	for i := cut; i > length/2; i-- {
+		if unicode.IsSpace(runes[i]) {
+			cut = i
+			break
+		}
+	}
+
+	return strings.TrimRightFunc(string(runes[:cut]), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
@@ -0,0 +1,42 @@
+package markdown
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// NewService renders Markdown, keeping up to cacheSize renders in memory
+func NewService(cacheSize int) Service {
+	return &service{
+		cache: newCache(cacheSize),
+	}
+}
+
+type Service interface {
+	Render(source string) (*Rendered, error)
+}
+
+type service struct {
+	cache *cache
+}
+
+// Render turns Markdown source into sanitized HTML and a plain text excerpt
+func (s service) Render(source string) (*Rendered, error) {
+	key := cacheKey(source)
+	if rendered, ok := s.cache.get(key); ok {
+		return rendered, nil
+	}
+
+	rendered, err := render(source)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.put(key, rendered)
+	return rendered, nil
+}
+
+func cacheKey(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:])
+}
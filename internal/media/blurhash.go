@@ -0,0 +1,115 @@
+package media
+
+import (
+	"image"
+	"math"
+	"strings"
+)
+
+const base83Characters = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// blurhash encodes img as a BlurHash (https://blurha.sh) placeholder with xComponents by yComponents components.
+// img should be small, every component is a pass over all of its pixels.
+func blurhash(img image.Image, xComponents, yComponents int) string {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	factors := make([][3]float64, 0, xComponents*yComponents)
+	for y := 0; y < yComponents; y++ {
+		for x := 0; x < xComponents; x++ {
+			var factor [3]float64
+			for py := 0; py < height; py++ {
+				for px := 0; px < width; px++ {
+					basis := math.Cos(math.Pi*float64(x)*float64(px)/float64(width)) *
+						math.Cos(math.Pi*float64(y)*float64(py)/float64(height))
+					r, g, b, _ := img.At(bounds.Min.X+px, bounds.Min.Y+py).RGBA()
+					factor[0] += basis * srgbToLinear(r>>8)
+					factor[1] += basis * srgbToLinear(g>>8)
+					factor[2] += basis * srgbToLinear(b>>8)
+				}
+			}
+
+			normalisation := 2.0
+			if x == 0 && y == 0 {
+				normalisation = 1
+			}
+			scale := normalisation / float64(width*height)
+			factors = append(factors, [3]float64{factor[0] * scale, factor[1] * scale, factor[2] * scale})
+		}
+	}
+
+	var hash strings.Builder
+	hash.WriteString(encode83((xComponents-1)+(yComponents-1)*9, 1))
+
+	maximumValue := 1.0
+	if len(factors) > 1 {
+		actualMaximum := 0.0
+		for _, factor := range factors[1:] {
+			for _, value := range factor {
+				actualMaximum = math.Max(actualMaximum, math.Abs(value))
+			}
+		}
+
+		quantisedMaximum := clamp(int(math.Floor(actualMaximum*166-0.5)), 0, 82)
+		maximumValue = float64(quantisedMaximum+1) / 166
+		hash.WriteString(encode83(quantisedMaximum, 1))
+	} else {
+		hash.WriteString(encode83(0, 1))
+	}
+
+	dc := factors[0]
+	hash.WriteString(encode83(linearToSRGB(dc[0])<<16+linearToSRGB(dc[1])<<8+linearToSRGB(dc[2]), 4))
+
+	for _, factor := range factors[1:] {
+		quantised := [3]int{}
+		for i, value := range factor {
+			quantised[i] = clamp(int(math.Floor(signPow(value/maximumValue, 0.5)*9+9.5)), 0, 18)
+		}
+		hash.WriteString(encode83(quantised[0]*19*19+quantised[1]*19+quantised[2], 2))
+	}
+
+	return hash.String()
+}
+
+func encode83(value, length int) string {
+	var encoded strings.Builder
+	for i := 1; i <= length; i++ {
+		digit := (value / int(math.Pow(83, float64(length-i)))) % 83
+		encoded.WriteByte(base83Characters[digit])
+	}
+
+	return encoded.String()
+}
+
+func srgbToLinear(value uint32) float64 {
+	v := float64(value) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(value float64) int {
+	v := math.Max(0, math.Min(1, value))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(value, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(value), exp), value)
+}
+
+func clamp(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+
+	return value
+}
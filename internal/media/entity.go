@@ -0,0 +1,56 @@
+package media
+
+import "image"
+
+// Processing status of an uploaded image
+const (
+	StatusReady  = "ready"
+	StatusFailed = "failed"
+)
+
+// Image entity represent images table in database. Source is the URL recorded in a photo column.
+type Image struct {
+	Source      string    `json:"-" db:"source"`
+	Width       int       `json:"width" db:"width"`
+	Height      int       `json:"height" db:"height"`
+	Placeholder string    `json:"placeholder" db:"placeholder"`
+	Variants    []Variant `json:"variants" db:"-"`
+}
+
+// Variant is a resized, re-encoded copy of an image
+type Variant struct {
+	Source string `json:"-" db:"source"`
+	Name   string `json:"name" db:"name"`
+	Format string `json:"format" db:"format"`
+	URL    string `json:"url" db:"url"`
+	Width  int    `json:"width" db:"width"`
+	Height int    `json:"height" db:"height"`
+}
+
+// variantSize bounds the longest edge of a variant, images are never upscaled
+type variantSize struct {
+	name    string
+	maxEdge int
+}
+
+var variantSizes = []variantSize{
+	{name: "thumbnail", maxEdge: 320},
+	{name: "medium", maxEdge: 800},
+	{name: "large", maxEdge: 1600},
+}
+
+// variantFormat is an encoding every variant size is written in. An optional format is only
+// kept when it comes out smaller than the last format, or when the image has transparency.
+type variantFormat struct {
+	name     string
+	ext      string
+	encode   func(img image.Image) ([]byte, error)
+	optional bool
+}
+
+// variantFormats lists WebP for clients that take it, JPEG for the rest. The WebP encoder is lossless,
+// which beats JPEG on flat graphics but usually not on photos.
+var variantFormats = []variantFormat{
+	{name: "webp", ext: ".webp", encode: encodeWebP, optional: true},
+	{name: "jpeg", ext: ".jpg", encode: encodeJPEG},
+}
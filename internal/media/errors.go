@@ -0,0 +1,9 @@
+package media
+
+import "github.com/pkg/errors"
+
+var (
+	ErrUnsupportedImage = errors.New("image can't be decoded")
+	ErrImageTooLarge    = errors.New("image has too many pixels")
+	ErrInternalServer   = errors.New("internal server error")
+)
@@ -0,0 +1,30 @@
+package media
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// StartProcessor processes newly uploaded images and deletes unused ones every interval until the process exits
+func StartProcessor(service Service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		for range ticker.C {
+			processed, err := service.ProcessPending(context.Background())
+			if err != nil {
+				logrus.Error("[error while processing uploaded images] ", err)
+			} else if processed != 0 {
+				logrus.Infof("Processed %d uploaded image(s)", processed)
+			}
+
+			deleted, err := service.CollectUnused(context.Background())
+			if err != nil {
+				logrus.Error("[error while deleting unused images] ", err)
+			} else if deleted != 0 {
+				logrus.Infof("Deleted %d unused image(s)", deleted)
+			}
+		}
+	}()
+}
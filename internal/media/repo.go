@@ -0,0 +1,176 @@
+package media
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+// photoSources gathers every photo URL still in use, trashed rows included since they can be restored
+const photoSources = `(
+	SELECT photo AS source FROM project_photos WHERE photo IS NOT NULL
+	UNION
+	SELECT photo AS source FROM jastip_members WHERE photo IS NOT NULL
+) photos`
+
+type Repo interface {
+	ListUnprocessed(prefix string, limit int) ([]string, error)
+	ListUnused(prefix string, limit int) ([]string, error)
+	IsUsed(source string) (bool, error)
+	ListVariantURLs(source string) ([]string, error)
+	Delete(source string) error
+	Save(image Image) error
+	MarkFailed(source, reason string) error
+	ListBySources(sources []string) ([]Image, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+// ListUnprocessed lists uploaded photos, recognized by the blob store URL prefix, that haven't been processed yet.
+// Pasted URLs of images hosted elsewhere are left alone.
+func (r repo) ListUnprocessed(prefix string, limit int) ([]string, error) {
+	sources := []string{}
+	err := r.db.Select(&sources, `
+		SELECT source FROM `+photoSources+`
+		WHERE left(source, length($1)) = $1 AND NOT EXISTS (SELECT 1 FROM images i WHERE i.source = photos.source)
+		LIMIT $2`, prefix, limit)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return sources, nil
+}
+
+// ListUnused lists processed images whose photo has since been replaced or deleted
+func (r repo) ListUnused(prefix string, limit int) ([]string, error) {
+	sources := []string{}
+	err := r.db.Select(&sources, `
+		SELECT source FROM images i
+		WHERE left(source, length($1)) = $1 AND NOT EXISTS (SELECT 1 FROM `+photoSources+` WHERE photos.source = i.source)
+		LIMIT $2`, prefix, limit)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return sources, nil
+}
+
+// IsUsed tells whether any photo still points to the source, which is shared by every upload of the same content
+func (r repo) IsUsed(source string) (bool, error) {
+	var used bool
+	err := r.db.Get(&used, "SELECT EXISTS (SELECT 1 FROM "+photoSources+" WHERE photos.source = $1)", source)
+	if err != nil {
+		return false, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return used, nil
+}
+
+func (r repo) ListVariantURLs(source string) ([]string, error) {
+	urls := []string{}
+	err := r.db.Select(&urls, "SELECT url FROM image_variants WHERE source=$1", source)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return urls, nil
+}
+
+// Delete removes the image along with its variants
+func (r repo) Delete(source string) error {
+	_, err := r.db.Exec("DELETE FROM images WHERE source=$1", source)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+func (r repo) Save(image Image) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO images (source, width, height, placeholder, status) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (source) DO UPDATE SET width = EXCLUDED.width, height = EXCLUDED.height, placeholder = EXCLUDED.placeholder,
+			status = EXCLUDED.status, error = NULL, processed_at = NOW()`,
+		image.Source, image.Width, image.Height, image.Placeholder, StatusReady)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	_, err = tx.Exec("DELETE FROM image_variants WHERE source=$1", image.Source)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	for _, variant := range image.Variants {
+		_, err = tx.Exec("INSERT INTO image_variants (source, name, format, url, width, height) VALUES ($1, $2, $3, $4, $5, $6)",
+			image.Source, variant.Name, variant.Format, variant.URL, variant.Width, variant.Height)
+		if err != nil {
+			return errors.Wrap(ErrInternalServer, err.Error())
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+// MarkFailed records an image that can't be processed, so it isn't retried on every run
+func (r repo) MarkFailed(source, reason string) error {
+	_, err := r.db.Exec(`
+		INSERT INTO images (source, status, error) VALUES ($1, $2, $3)
+		ON CONFLICT (source) DO UPDATE SET status = EXCLUDED.status, error = EXCLUDED.error, processed_at = NOW()`,
+		source, StatusFailed, reason)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+// ListBySources lists processed images along with their variants
+func (r repo) ListBySources(sources []string) ([]Image, error) {
+	images := []Image{}
+	if len(sources) == 0 {
+		return images, nil
+	}
+
+	err := r.db.Select(&images, "SELECT source, width, height, placeholder FROM images WHERE source = ANY($1) AND status=$2", pq.Array(sources), StatusReady)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	variants := []Variant{}
+	err = r.db.Select(&variants, "SELECT source, name, format, url, width, height FROM image_variants WHERE source = ANY($1) ORDER BY source, width", pq.Array(sources))
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	bySource := map[string][]Variant{}
+	for _, variant := range variants {
+		bySource[variant.Source] = append(bySource[variant.Source], variant)
+	}
+
+	for i := range images {
+		images[i].Variants = bySource[images[i].Source]
+	}
+
+	return images, nil
+}
@@ -0,0 +1,223 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/storage"
+	"image"
+	"io/ioutil"
+	"path"
+	"strings"
+)
+
+// batchSize is how many images are processed per run
+const batchSize = 10
+
+func NewService(repo Repo, store storage.BlobStore) Service {
+	return &service{
+		repo:  repo,
+		store: store,
+	}
+}
+
+type Service interface {
+	ProcessPending(ctx context.Context) (int, error)
+	Release(ctx context.Context, sources ...string) error
+	CollectUnused(ctx context.Context) (int, error)
+	Lookup(sources []string) (map[string]*Image, error)
+	Load(ctx context.Context, source string, maxEdge int) (image.Image, error)
+}
+
+type service struct {
+	repo  Repo
+	store storage.BlobStore
+}
+
+// ProcessPending makes variants and placeholders for uploaded images that don't have them yet.
+// An image that fails is marked as failed and skipped, so one bad upload doesn't block the rest.
+func (s service) ProcessPending(ctx context.Context) (int, error) {
+	prefix := s.store.URL("")
+	sources, err := s.repo.ListUnprocessed(prefix, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, source := range sources {
+		err = s.process(ctx, source, strings.TrimPrefix(source, prefix))
+		if err != nil {
+			cause := errors.Cause(err)
+			if cause != ErrUnsupportedImage && cause != ErrImageTooLarge && cause != storage.ErrBlobNotFound && cause != storage.ErrInvalidKey {
+				return processed, err
+			}
+
+			err = s.repo.MarkFailed(source, err.Error())
+			if err != nil {
+				return processed, err
+			}
+			continue
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+func (s service) process(ctx context.Context, source, key string) error {
+	img, orientation, err := s.read(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	width, height := orientedSize(img, orientation)
+	result := Image{
+		Source: source,
+		Width:  width,
+		Height: height,
+	}
+
+	// Variant keys derive from the content addressed source key, so they are just as immutable
+	base := strings.TrimSuffix(key, path.Ext(key))
+	for _, size := range variantSizes {
+		resized := resize(img, orientation, size.maxEdge)
+
+		encoded := make([][]byte, len(variantFormats))
+		for i, format := range variantFormats {
+			encoded[i], err = format.encode(resized)
+			if err != nil {
+				return err
+			}
+		}
+
+		fallback := encoded[len(encoded)-1]
+		for i, format := range variantFormats {
+			if format.optional && len(encoded[i]) >= len(fallback) && isOpaque(resized) {
+				continue
+			}
+
+			variantKey := base + "_" + size.name + format.ext
+			err = s.store.Put(ctx, variantKey, bytes.NewReader(encoded[i]))
+			if err != nil {
+				return err
+			}
+
+			result.Variants = append(result.Variants, Variant{
+				Name:   size.name,
+				Format: format.name,
+				URL:    s.store.URL(variantKey),
+				Width:  resized.Bounds().Dx(),
+				Height: resized.Bounds().Dy(),
+			})
+		}
+	}
+
+	result.Placeholder = blurhash(resize(img, orientation, placeholderSize), 4, 3)
+
+	return s.repo.Save(result)
+}
+
+// Release deletes the stored files of photo URLs that were replaced or deleted, along with their variants.
+// Uploads are content addressed so one file can back several photos, it's only deleted once none of them is left.
+func (s service) Release(ctx context.Context, sources ...string) error {
+	prefix := s.store.URL("")
+	for _, source := range sources {
+		key := strings.TrimPrefix(source, prefix)
+		if key == "" || key == source {
+			continue
+		}
+
+		used, err := s.repo.IsUsed(source)
+		if err != nil {
+			return err
+		}
+		if used {
+			continue
+		}
+
+		variants, err := s.repo.ListVariantURLs(source)
+		if err != nil {
+			return err
+		}
+
+		// The files go first, so the image row is still around to retry from when deleting one fails
+		for _, url := range variants {
+			err = s.store.Delete(ctx, strings.TrimPrefix(url, prefix))
+			if err != nil {
+				return err
+			}
+		}
+
+		err = s.store.Delete(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		err = s.repo.Delete(source)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CollectUnused releases processed images that no photo uses anymore, catching whatever Release missed
+func (s service) CollectUnused(ctx context.Context) (int, error) {
+	sources, err := s.repo.ListUnused(s.store.URL(""), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	err = s.Release(ctx, sources...)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(sources), nil
+}
+
+// Lookup returns the processed images of photo URLs, keyed by URL. Unprocessed photos are missing from the map.
+func (s service) Lookup(sources []string) (map[string]*Image, error) {
+	images, err := s.repo.ListBySources(sources)
+	if err != nil {
+		return nil, err
+	}
+
+	bySource := make(map[string]*Image, len(images))
+	for i := range images {
+		bySource[images[i].Source] = &images[i]
+	}
+
+	return bySource, nil
+}
+
+// Load decodes an uploaded image, upright and scaled down so its longest edge is at most maxEdge
+func (s service) Load(ctx context.Context, source string, maxEdge int) (image.Image, error) {
+	prefix := s.store.URL("")
+	if !strings.HasPrefix(source, prefix) {
+		return nil, errors.Wrap(storage.ErrInvalidKey, source)
+	}
+
+	img, orientation, err := s.read(ctx, strings.TrimPrefix(source, prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	return resize(img, orientation, maxEdge), nil
+}
+
+func (s service) read(ctx context.Context, key string) (image.Image, int, error) {
+	blob, err := s.store.Get(ctx, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer blob.Close()
+
+	data, err := ioutil.ReadAll(blob)
+	if err != nil {
+		return nil, 0, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return decode(data)
+}
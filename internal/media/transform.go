@@ -0,0 +1,142 @@
+package media
+
+import (
+	"bytes"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/storage"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+)
+
+const (
+	// maxPixels guards against decompression bombs, a 50 megapixel image is about 200 MB decoded
+	maxPixels = 50_000_000
+
+	jpegQuality = 82
+
+	// placeholderSize is the longest edge the image is shrunk to before computing its blurhash
+	placeholderSize = 32
+)
+
+// decode decodes an uploaded image, refusing images too large to decode safely.
+// The returned orientation is the EXIF orientation still to be applied to the pixels.
+func decode(data []byte) (image.Image, int, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, errors.Wrap(ErrUnsupportedImage, err.Error())
+	}
+
+	if config.Width*config.Height > maxPixels {
+		return nil, 0, errors.Wrapf(ErrImageTooLarge, "%dx%d", config.Width, config.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, errors.Wrap(ErrUnsupportedImage, err.Error())
+	}
+
+	return img, storage.JPEGOrientation(data), nil
+}
+
+// orientedSize is the size of img once its orientation is applied
+func orientedSize(img image.Image, orientation int) (int, int) {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	if orientation >= 5 {
+		return height, width
+	}
+
+	return width, height
+}
+
+// resize scales img so that, once oriented, its longest edge is at most maxEdge.
+// Transparent pixels are flattened onto white since JPEG has no alpha channel.
+func resize(img image.Image, orientation, maxEdge int) image.Image {
+	width, height := orientedSize(img, orientation)
+	if longest := max(width, height); longest > maxEdge {
+		width = max(1, width*maxEdge/longest)
+		height = max(1, height*maxEdge/longest)
+	}
+
+	// Scale in the stored orientation, then rotate the much smaller result
+	if orientation >= 5 {
+		width, height = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Over, nil)
+
+	return orient(dst, orientation)
+}
+
+// orient applies an EXIF orientation, so the image is upright without relying on metadata
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				dx, dy = width-1-x, y
+			case 3: // rotated 180°
+				dx, dy = width-1-x, height-1-y
+			case 4: // mirrored vertically
+				dx, dy = x, height-1-y
+			case 5: // mirrored along the top-left diagonal
+				dx, dy = y, x
+			case 6: // rotated 90° clockwise
+				dx, dy = height-1-y, x
+			case 7: // mirrored along the top-right diagonal
+				dx, dy = height-1-y, width-1-x
+			case 8: // rotated 90° counter clockwise
+				dx, dy = y, width-1-x
+			}
+			dst.SetRGBA(dx, dy, src.RGBAAt(x, y))
+		}
+	}
+
+	return dst
+}
+
+// encodeJPEG re-encodes img, which drops any metadata the upload carried
+func encodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return buf.Bytes(), nil
+}
+
+// isOpaque tells whether img has no transparent pixels, which JPEG can't hold
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+
+	return false
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
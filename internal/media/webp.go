@@ -0,0 +1,541 @@
+package media
+
+import (
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"image"
+	"image/color"
+	"sort"
+)
+
+// Neither the standard library nor x/image can encode WebP, so variants are written as lossless WebP (VP8L)
+// by this small encoder: a subtract green and a predictor transform followed by one set of prefix codes.
+const (
+	vp8lSignature = 0x2f
+	vp8lMaxSize   = 1 << 14
+
+	// predictorTileBits sizes the tiles which each pick their own predictor, 16x16 pixels
+	predictorTileBits = 4
+
+	subtractGreenTransform = 2
+	predictorTransform     = 0
+
+	numLiteralCodes  = 256
+	numLengthCodes   = 24
+	numDistanceCodes = 40
+
+	maxCodeLength           = 15
+	maxCodeLengthCodeLength = 7
+)
+
+// predictorModes are the predictors tried for each tile, ones using the top right pixel are left out
+var predictorModes = []uint32{1, 2, 7, 11, 12, 13}
+
+// codeLengthCodeOrder is the order the code length code lengths are written in
+var codeLengthCodeOrder = []int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// encodeWebP encodes img as a lossless WebP image
+func encodeWebP(img image.Image) ([]byte, error) {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	if width < 1 || height < 1 || width > vp8lMaxSize || height > vp8lMaxSize {
+		return nil, errors.Wrapf(ErrInternalServer, "can't encode a %dx%d webp", width, height)
+	}
+
+	pixels, alphaUsed := argbPixels(img)
+
+	w := &bitWriter{}
+	w.write(vp8lSignature, 8)
+	w.write(uint32(width-1), 14)
+	w.write(uint32(height-1), 14)
+	if alphaUsed {
+		w.write(1, 1)
+	} else {
+		w.write(0, 1)
+	}
+	w.write(0, 3)
+
+	subtractGreen(pixels)
+	w.write(1, 1)
+	w.write(subtractGreenTransform, 2)
+
+	modes, residuals := predict(pixels, width, height)
+	w.write(1, 1)
+	w.write(predictorTransform, 2)
+	w.write(predictorTileBits-2, 3)
+	writeEntropyImage(w, modes, false)
+
+	w.write(0, 1)
+	writeEntropyImage(w, residuals, true)
+
+	return riffWebP(w.bytes()), nil
+}
+
+// argbPixels flattens img to non-premultiplied ARGB, row by row
+func argbPixels(img image.Image) ([]uint32, bool) {
+	bounds := img.Bounds()
+	pixels := make([]uint32, 0, bounds.Dx()*bounds.Dy())
+	alphaUsed := false
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A != 0xff {
+				alphaUsed = true
+			}
+			pixels = append(pixels, uint32(c.A)<<24|uint32(c.R)<<16|uint32(c.G)<<8|uint32(c.B))
+		}
+	}
+
+	return pixels, alphaUsed
+}
+
+// subtractGreen takes green out of red and blue, which are usually correlated with it
+func subtractGreen(pixels []uint32) {
+	for i, p := range pixels {
+		green := p >> 8 & 0xff
+		red := (p>>16 - green) & 0xff
+		blue := (p - green) & 0xff
+		pixels[i] = p&0xff00ff00 | red<<16 | blue
+	}
+}
+
+// predict picks the predictor leaving the smallest residuals for each tile and returns the
+// predictor image, with the mode of every tile in green, along with the residuals
+func predict(pixels []uint32, width, height int) ([]uint32, []uint32) {
+	tileSize := 1 << predictorTileBits
+	tilesX := (width + tileSize - 1) / tileSize
+	tilesY := (height + tileSize - 1) / tileSize
+
+	modes := make([]uint32, tilesX*tilesY)
+	residuals := make([]uint32, len(pixels))
+
+	for ty := 0; ty < tilesY; ty++ {
+		for tx := 0; tx < tilesX; tx++ {
+			x0, y0 := tx*tileSize, ty*tileSize
+			x1, y1 := minInt(x0+tileSize, width), minInt(y0+tileSize, height)
+
+			best, bestCost := predictorModes[0], -1
+			for _, mode := range predictorModes {
+				cost := 0
+				for y := y0; y < y1; y++ {
+					for x := x0; x < x1; x++ {
+						cost += residualCost(subPixels(pixels[y*width+x], predictor(pixels, x, y, width, mode)))
+					}
+				}
+				if bestCost < 0 || cost < bestCost {
+					best, bestCost = mode, cost
+				}
+			}
+
+			modes[ty*tilesX+tx] = 0xff000000 | best<<8
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					residuals[y*width+x] = subPixels(pixels[y*width+x], predictor(pixels, x, y, width, best))
+				}
+			}
+		}
+	}
+
+	return modes, residuals
+}
+
+// predictor predicts the pixel at x, y from the already decoded pixels left of and above it
+func predictor(pixels []uint32, x, y, width int, mode uint32) uint32 {
+	i := y*width + x
+	switch {
+	case x == 0 && y == 0:
+		return 0xff000000
+	case y == 0:
+		return pixels[i-1]
+	case x == 0:
+		return pixels[i-width]
+	}
+
+	left, top, topLeft := pixels[i-1], pixels[i-width], pixels[i-width-1]
+	switch mode {
+	case 1:
+		return left
+	case 2:
+		return top
+	case 7:
+		return average2(left, top)
+	case 11:
+		return selectPredictor(left, top, topLeft)
+	case 12:
+		return mapChannels(func(l, t, tl int) int { return l + t - tl }, left, top, topLeft)
+	default:
+		return mapChannels(func(a, tl, _ int) int { return a + (a-tl)/2 }, average2(left, top), topLeft, 0)
+	}
+}
+
+func average2(a, b uint32) uint32 {
+	return mapChannels(func(a, b, _ int) int { return (a + b) / 2 }, a, b, 0)
+}
+
+func selectPredictor(left, top, topLeft uint32) uint32 {
+	distLeft, distTop := 0, 0
+	for shift := uint(0); shift < 32; shift += 8 {
+		l, t, tl := int(left>>shift&0xff), int(top>>shift&0xff), int(topLeft>>shift&0xff)
+		estimate := l + t - tl
+		distLeft += absInt(estimate - l)
+		distTop += absInt(estimate - t)
+	}
+
+	if distLeft < distTop {
+		return left
+	}
+
+	return top
+}
+
+// mapChannels applies fn to each channel of the pixels, clamping the result to a byte
+func mapChannels(fn func(a, b, c int) int, a, b, c uint32) uint32 {
+	var out uint32
+	for shift := uint(0); shift < 32; shift += 8 {
+		v := fn(int(a>>shift&0xff), int(b>>shift&0xff), int(c>>shift&0xff))
+		if v < 0 {
+			v = 0
+		} else if v > 0xff {
+			v = 0xff
+		}
+		out |= uint32(v) << shift
+	}
+
+	return out
+}
+
+// subPixels subtracts each channel modulo 256
+func subPixels(a, b uint32) uint32 {
+	alphaGreen := 0x00ff00ff + (a & 0xff00ff00) - (b & 0xff00ff00)
+	redBlue := 0xff00ff00 + (a & 0x00ff00ff) - (b & 0x00ff00ff)
+	return alphaGreen&0xff00ff00 | redBlue&0x00ff00ff
+}
+
+// residualCost estimates how well a residual compresses, residuals near zero either way are cheap
+func residualCost(p uint32) int {
+	cost := 0
+	for shift := uint(0); shift < 32; shift += 8 {
+		v := int(p >> shift & 0xff)
+		cost += minInt(v, 256-v)
+	}
+
+	return cost
+}
+
+// writeEntropyImage writes pixels as literals coded with one set of prefix codes and no color cache.
+// Only the main image says whether it uses meta prefix codes.
+func writeEntropyImage(w *bitWriter, pixels []uint32, main bool) {
+	w.write(0, 1)
+	if main {
+		w.write(0, 1)
+	}
+
+	green := make([]uint32, numLiteralCodes+numLengthCodes)
+	red := make([]uint32, numLiteralCodes)
+	blue := make([]uint32, numLiteralCodes)
+	alpha := make([]uint32, numLiteralCodes)
+	for _, p := range pixels {
+		green[p>>8&0xff]++
+		red[p>>16&0xff]++
+		blue[p&0xff]++
+		alpha[p>>24]++
+	}
+
+	greenCode := writePrefixCode(w, green)
+	redCode := writePrefixCode(w, red)
+	blueCode := writePrefixCode(w, blue)
+	alphaCode := writePrefixCode(w, alpha)
+	writePrefixCode(w, make([]uint32, numDistanceCodes))
+
+	for _, p := range pixels {
+		greenCode.write(w, int(p>>8&0xff))
+		redCode.write(w, int(p>>16&0xff))
+		blueCode.write(w, int(p&0xff))
+		alphaCode.write(w, int(p>>24))
+	}
+}
+
+// prefixCode holds the bit reversed canonical code of each symbol, ready for the LSB first bit writer
+type prefixCode struct {
+	codes   []uint32
+	lengths []uint8
+}
+
+func (c prefixCode) write(w *bitWriter, symbol int) {
+	w.write(c.codes[symbol], uint(c.lengths[symbol]))
+}
+
+// writePrefixCode writes the code for a histogram and returns it. Up to two literal symbols fit in a simple code,
+// where a lone symbol takes no bits at all.
+func writePrefixCode(w *bitWriter, histogram []uint32) prefixCode {
+	var symbols []int
+	for symbol, count := range histogram {
+		if count > 0 {
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	if len(symbols) == 0 {
+		symbols = []int{0}
+	}
+
+	if len(symbols) <= 2 && symbols[len(symbols)-1] < numLiteralCodes {
+		w.write(1, 1)
+		w.write(uint32(len(symbols)-1), 1)
+		if symbols[0] <= 1 {
+			w.write(0, 1)
+			w.write(uint32(symbols[0]), 1)
+		} else {
+			w.write(1, 1)
+			w.write(uint32(symbols[0]), 8)
+		}
+		if len(symbols) == 2 {
+			w.write(uint32(symbols[1]), 8)
+		}
+
+		lengths := make([]uint8, len(histogram))
+		if len(symbols) == 2 {
+			lengths[symbols[0]], lengths[symbols[1]] = 1, 1
+		}
+		return prefixCode{codes: canonicalCodes(lengths), lengths: lengths}
+	}
+
+	lengths := codeLengths(histogram, maxCodeLength)
+	w.write(0, 1)
+	writeCodeLengths(w, lengths)
+
+	return prefixCode{codes: canonicalCodes(lengths), lengths: lengths}
+}
+
+// writeCodeLengths writes the code lengths of a normal code, themselves prefix coded with runs of zeros collapsed
+func writeCodeLengths(w *bitWriter, lengths []uint8) {
+	type token struct {
+		symbol, extra int
+	}
+
+	var tokens []token
+	for i := 0; i < len(lengths); {
+		run := 1
+		for i+run < len(lengths) && lengths[i] == 0 && lengths[i+run] == 0 && run < 138 {
+			run++
+		}
+
+		switch {
+		case lengths[i] == 0 && run >= 11:
+			tokens = append(tokens, token{18, run - 11})
+		case lengths[i] == 0 && run >= 3:
+			tokens = append(tokens, token{17, run - 3})
+		default:
+			run = 1
+			tokens = append(tokens, token{int(lengths[i]), 0})
+		}
+		i += run
+	}
+
+	histogram := make([]uint32, len(codeLengthCodeOrder))
+	for _, t := range tokens {
+		histogram[t.symbol]++
+	}
+
+	// A code of a single symbol would take no bits, which normal codes can't express
+	used := 0
+	for _, count := range histogram {
+		if count > 0 {
+			used++
+		}
+	}
+	if used == 1 {
+		if histogram[0] == 0 {
+			histogram[0] = 1
+		} else {
+			histogram[1] = 1
+		}
+	}
+
+	codeLengthLengths := codeLengths(histogram, maxCodeLengthCodeLength)
+	count := 4
+	for i, symbol := range codeLengthCodeOrder {
+		if codeLengthLengths[symbol] != 0 && i+1 > count {
+			count = i + 1
+		}
+	}
+
+	w.write(uint32(count-4), 4)
+	for _, symbol := range codeLengthCodeOrder[:count] {
+		w.write(uint32(codeLengthLengths[symbol]), 3)
+	}
+
+	// Every symbol of the alphabet has a length written
+	w.write(0, 1)
+
+	code := prefixCode{codes: canonicalCodes(codeLengthLengths), lengths: codeLengthLengths}
+	for _, t := range tokens {
+		code.write(w, t.symbol)
+		switch t.symbol {
+		case 17:
+			w.write(uint32(t.extra), 3)
+		case 18:
+			w.write(uint32(t.extra), 7)
+		}
+	}
+}
+
+// codeLengths builds Huffman code lengths for a histogram, flattening the histogram until no code is longer than maxLength
+func codeLengths(histogram []uint32, maxLength int) []uint8 {
+	type node struct {
+		count       uint64
+		left, right int
+		symbol      int
+	}
+
+	counts := append([]uint32{}, histogram...)
+	for {
+		var nodes []node
+		for symbol, count := range counts {
+			if count > 0 {
+				nodes = append(nodes, node{count: uint64(count), left: -1, right: -1, symbol: symbol})
+			}
+		}
+
+		lengths := make([]uint8, len(counts))
+		if len(nodes) < 2 {
+			for _, n := range nodes {
+				lengths[n.symbol] = 1
+			}
+			return lengths
+		}
+
+		sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].count < nodes[j].count })
+
+		// Two queues: leaves sorted by count, and merged nodes which come out sorted by construction
+		leaves := len(nodes)
+		nextLeaf, nextMerged := 0, leaves
+		pick := func() int {
+			if nextLeaf < leaves && (nextMerged >= len(nodes) || nodes[nextLeaf].count <= nodes[nextMerged].count) {
+				nextLeaf++
+				return nextLeaf - 1
+			}
+			nextMerged++
+			return nextMerged - 1
+		}
+		for i := 0; i < leaves-1; i++ {
+			a := pick()
+			b := pick()
+			nodes = append(nodes, node{count: nodes[a].count + nodes[b].count, left: a, right: b, symbol: -1})
+		}
+
+		// Children always come before their parent, so walking down from the root sets every depth
+		depths := make([]int, len(nodes))
+		tooLong := false
+		for i := len(nodes) - 1; i >= 0; i-- {
+			if nodes[i].symbol >= 0 {
+				lengths[nodes[i].symbol] = uint8(depths[i])
+				tooLong = tooLong || depths[i] > maxLength
+				continue
+			}
+			depths[nodes[i].left] = depths[i] + 1
+			depths[nodes[i].right] = depths[i] + 1
+		}
+
+		if !tooLong {
+			return lengths
+		}
+
+		for i, count := range counts {
+			if count > 1 {
+				counts[i] = count / 2
+			}
+		}
+	}
+}
+
+// canonicalCodes assigns canonical codes to code lengths, bit reversed since VP8L reads codes from the least significant bit
+func canonicalCodes(lengths []uint8) []uint32 {
+	var lengthCount [maxCodeLength + 1]uint32
+	for _, length := range lengths {
+		if length > 0 {
+			lengthCount[length]++
+		}
+	}
+
+	var next [maxCodeLength + 2]uint32
+	code := uint32(0)
+	for length := 1; length <= maxCodeLength; length++ {
+		code = (code + lengthCount[length-1]) << 1
+		next[length] = code
+	}
+
+	codes := make([]uint32, len(lengths))
+	for symbol, length := range lengths {
+		if length == 0 {
+			continue
+		}
+
+		code := next[length]
+		next[length]++
+
+		var reversed uint32
+		for i := uint8(0); i < length; i++ {
+			reversed = reversed<<1 | code>>i&1
+		}
+		codes[symbol] = reversed
+	}
+
+	return codes
+}
+
+// riffWebP wraps a VP8L bitstream in the RIFF container of a WebP file
+func riffWebP(vp8l []byte) []byte {
+	padded := len(vp8l) + len(vp8l)%2
+
+	out := make([]byte, 20+padded)
+	copy(out, "RIFF")
+	binary.LittleEndian.PutUint32(out[4:], uint32(12+padded))
+	copy(out[8:], "WEBPVP8L")
+	binary.LittleEndian.PutUint32(out[16:], uint32(len(vp8l)))
+	copy(out[20:], vp8l)
+
+	return out
+}
+
+// bitWriter packs bits least significant first, as VP8L reads them
+type bitWriter struct {
+	buf   []byte
+	bits  uint64
+	nbits uint
+}
+
+func (w *bitWriter) write(value uint32, n uint) {
+	w.bits |= uint64(value) << w.nbits
+	w.nbits += n
+	for w.nbits >= 8 {
+		w.buf = append(w.buf, byte(w.bits))
+		w.bits >>= 8
+		w.nbits -= 8
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	if w.nbits > 0 {
+		w.buf = append(w.buf, byte(w.bits))
+		w.bits, w.nbits = 0, 0
+	}
+
+	return w.buf
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+
+	return a
+}
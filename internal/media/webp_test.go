@@ -0,0 +1,123 @@
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"golang.org/x/image/webp"
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+)
+
+func TestEncodeWebPRoundTrip(t *testing.T) {
+	sizes := []image.Point{{1, 1}, {2, 3}, {16, 16}, {17, 9}, {64, 33}, {300, 200}}
+	fills := []struct {
+		name  string
+		alpha bool
+		noise bool
+	}{
+		{name: "opaque gradient"},
+		{name: "opaque noise", noise: true},
+		{name: "alpha gradient", alpha: true},
+		{name: "alpha noise", alpha: true, noise: true},
+	}
+
+	for _, size := range sizes {
+		for _, fill := range fills {
+			t.Run(fmt.Sprintf("%dx%d %s", size.X, size.Y, fill.name), func(t *testing.T) {
+				src := testImage(size.X, size.Y, fill.alpha, fill.noise)
+
+				encoded, err := encodeWebP(src)
+				if err != nil {
+					t.Fatalf("encodeWebP: %v", err)
+				}
+
+				decoded, err := webp.Decode(bytes.NewReader(encoded))
+				if err != nil {
+					t.Fatalf("webp.Decode: %v", err)
+				}
+
+				if decoded.Bounds().Size() != size {
+					t.Fatalf("decoded size %v, want %v", decoded.Bounds().Size(), size)
+				}
+
+				for y := 0; y < size.Y; y++ {
+					for x := 0; x < size.X; x++ {
+						want := src.NRGBAAt(x, y)
+						got := color.NRGBAModel.Convert(decoded.At(decoded.Bounds().Min.X+x, decoded.Bounds().Min.Y+y)).(color.NRGBA)
+						if got != want {
+							t.Fatalf("pixel (%d, %d) is %v, want %v", x, y, got, want)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestEncodeWebPOffsetBounds(t *testing.T) {
+	src := testImage(40, 30, true, true)
+	sub := src.SubImage(image.Rect(5, 7, 29, 25)).(*image.NRGBA)
+
+	encoded, err := encodeWebP(sub)
+	if err != nil {
+		t.Fatalf("encodeWebP: %v", err)
+	}
+
+	decoded, err := webp.Decode(bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("webp.Decode: %v", err)
+	}
+
+	bounds := sub.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			want := sub.NRGBAAt(x, y)
+			got := color.NRGBAModel.Convert(decoded.At(x-bounds.Min.X, y-bounds.Min.Y)).(color.NRGBA)
+			if got != want {
+				t.Fatalf("pixel (%d, %d) is %v, want %v", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestEncodeWebPRejectsBadSizes(t *testing.T) {
+	for _, rect := range []image.Rectangle{image.Rect(0, 0, 0, 10), image.Rect(0, 0, vp8lMaxSize+1, 1)} {
+		_, err := encodeWebP(image.NewNRGBA(rect))
+		if err == nil {
+			t.Errorf("encodeWebP of a %v image succeeded, want an error", rect.Size())
+		}
+	}
+}
+
+// testImage draws a gradient, optionally with noise on top, so both flat and busy areas get encoded
+func testImage(width, height int, alpha, noise bool) *image.NRGBA {
+	rnd := rand.New(rand.NewSource(int64(width*1000 + height)))
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.NRGBA{
+				R: uint8(x * 255 / width),
+				G: uint8(y * 255 / height),
+				B: uint8((x + y) * 3),
+				A: 0xff,
+			}
+			if noise {
+				c.R ^= uint8(rnd.Intn(64))
+				c.G ^= uint8(rnd.Intn(64))
+				c.B ^= uint8(rnd.Intn(64))
+			}
+			if alpha {
+				c.A = uint8(x * 255 / width)
+				if noise {
+					c.A = uint8(rnd.Intn(256))
+				}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+
+	return img
+}
@@ -0,0 +1,94 @@
+package member
+
+import (
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/media"
+)
+
+// Member entity represent jastip_members table in database
+type Member struct {
+	ID       int     `json:"id" db:"id"`
+	Name     string  `json:"name" db:"name"`
+	Slug     string  `json:"slug" db:"slug"`
+	Photo    *string `json:"photo" db:"photo"`
+	Position int     `json:"position" db:"position"`
+	Featured bool    `json:"featured" db:"featured"`
+	// Image holds the variants of an uploaded photo once they are processed
+	Image *media.Image `json:"image" db:"-"`
+}
+
+// Project is a project credited to a member through project_members table
+type Project struct {
+	ID         int    `json:"id" db:"id"`
+	Name       string `json:"name" db:"name"`
+	Slug       string `json:"slug" db:"slug"`
+	ClientName string `json:"client_name" db:"client_name"`
+	Role       string `json:"role" db:"role"`
+}
+
+// Detail is a member along with the projects the member worked on
+type Detail struct {
+	Member
+	Projects []Project `json:"projects"`
+}
+
+type ListMember struct {
+	Members []Member `json:"members"`
+	Count   int      `json:"count"`
+	listquery.Page
+}
+
+// listConfig whitelists sort and filter parameters for List
+var listConfig = listquery.Config{
+	Fields: map[string]listquery.Field{
+		"id":       {Column: "id", Type: listquery.TypeInt, Sortable: true, Filterable: true},
+		"name":     {Column: "name", Sortable: true, Filterable: true},
+		"slug":     {Column: "slug", Filterable: true},
+		"position": {Column: "position", Type: listquery.TypeInt, Sortable: true},
+		"featured": {Column: "featured", Type: listquery.TypeBool, Sortable: true, Filterable: true},
+	},
+	IDField:      "id",
+	DefaultSort:  "position",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// ListMemberAPIResponse API response for List
+type ListMemberAPIResponse struct {
+	Status  int         `json:"status"`
+	Message string      `json:"message"`
+	Data    *ListMember `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
+
+type GetMemberByIDAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Detail  `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// CreateMemberAPIRequest create and update member request body from client
+type CreateMemberAPIRequest struct {
+	Name     string  `json:"name"`
+	Photo    *string `json:"photo"`
+	Featured bool    `json:"featured"`
+}
+
+type CreateMemberAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Member  `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// ReorderAPIRequest reorder request body from client, ids are ordered from first to last
+type ReorderAPIRequest struct {
+	IDs []int `json:"ids"`
+}
+
+type ReorderAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Errors  []string `json:"errors,omitempty"`
+}
@@ -0,0 +1,8 @@
+package member
+
+import "github.com/pkg/errors"
+
+var (
+	ErrMemberNotFound = errors.New("member not found")
+	ErrInternalServer = errors.New("internal server error")
+)
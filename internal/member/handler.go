@@ -0,0 +1,367 @@
+package member
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/revision"
+	"github.com/rafimuhammad01/portofolio-api/internal/storage"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) GetAllMember(c *gin.Context) {
+	// Input Validation
+	q, errorList := listquery.Parse(c.Request.URL.Query(), listConfig)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ListMemberAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.List(q)
+	if err != nil {
+		logrus.Error("[error while using list member service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &ListMemberAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+// GetMemberByID accepts either the numeric id or the slug of a member.
+// Old slugs of renamed members are redirected to the current one.
+func (h *Handler) GetMemberByID(c *gin.Context) {
+	var res *Detail
+
+	preview, err := utils.IsPreview(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, GetMemberByIDAPIResponse{
+			Status:  http.StatusUnauthorized,
+			Message: "unauthorized",
+			Errors:  []string{errors.Cause(err).Error()},
+		})
+		return
+	}
+
+	slug := c.Param("id")
+	memberID, atoiErr := strconv.Atoi(slug)
+	if atoiErr == nil {
+		res, err = h.service.Get(memberID, preview)
+	} else {
+		res, err = h.service.GetBySlug(slug, preview)
+		if errors.Cause(err) == ErrMemberNotFound {
+			newSlug, resolveErr := h.service.ResolveSlug(slug)
+			if resolveErr == nil {
+				c.Redirect(http.StatusMovedPermanently, utils.SlugRedirectLocation(c.Request.URL, slug, newSlug))
+				return
+			}
+			if errors.Cause(resolveErr) != ErrMemberNotFound {
+				err = resolveErr
+			}
+		}
+	}
+
+	if err != nil {
+		if errors.Cause(err) == ErrMemberNotFound {
+			c.JSON(http.StatusNotFound, GetMemberByIDAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrMemberNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using get member service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, GetMemberByIDAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) CreateMember(c *gin.Context) {
+	var requestBody CreateMemberAPIRequest
+
+	payload, err := utils.GetPayloadFromContext(c)
+	if err != nil {
+		logrus.Error("[error while extracting context] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	// Input Validation
+	errorList := bindMemberRequest(c, &requestBody)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &CreateMemberAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Create(requestBody.Name, requestBody.Photo, requestBody.Featured, payload.UserID)
+	if err != nil {
+		logrus.Error("[error while using create member service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusCreated, &CreateMemberAPIResponse{
+		Status:  http.StatusCreated,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) UpdateMember(c *gin.Context) {
+	var requestBody CreateMemberAPIRequest
+
+	payload, err := utils.GetPayloadFromContext(c)
+	if err != nil {
+		logrus.Error("[error while extracting context] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	// Input Validation
+	memberID, err := strconv.Atoi(c.Param("id"))
+	errorList := bindMemberRequest(c, &requestBody)
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &CreateMemberAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Update(c, memberID, requestBody.Name, requestBody.Photo, requestBody.Featured, payload.UserID)
+	if err != nil {
+		if errors.Cause(err) == ErrMemberNotFound {
+			c.JSON(http.StatusNotFound, &CreateMemberAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrMemberNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using update member service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &CreateMemberAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) UploadMemberPhoto(c *gin.Context) {
+	var errorList []string
+
+	payload, err := utils.GetPayloadFromContext(c)
+	if err != nil {
+		logrus.Error("[error while extracting context] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	// Input Validation
+	memberID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	image, err := storage.ReadUpload(c, "photo", utils.GetMaxUploadSize())
+	if err != nil {
+		switch errors.Cause(err) {
+		case storage.ErrFileTooLarge:
+			c.JSON(http.StatusRequestEntityTooLarge, &CreateMemberAPIResponse{
+				Status:  http.StatusRequestEntityTooLarge,
+				Message: "request entity too large",
+				Errors:  []string{storage.ErrFileTooLarge.Error()},
+			})
+			return
+		case storage.ErrMissingFile:
+			errorList = append(errorList, "photo is required")
+		default:
+			logrus.Error("[error while reading uploaded photo] ", err)
+			c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+			return
+		}
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &CreateMemberAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.UploadPhoto(c, memberID, image, payload.UserID)
+	if err != nil {
+		switch errors.Cause(err) {
+		case storage.ErrUnsupportedFileType:
+			c.JSON(http.StatusUnsupportedMediaType, &CreateMemberAPIResponse{
+				Status:  http.StatusUnsupportedMediaType,
+				Message: "unsupported media type",
+				Errors:  []string{storage.ErrUnsupportedFileType.Error()},
+			})
+		case ErrMemberNotFound:
+			c.JSON(http.StatusNotFound, &CreateMemberAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrMemberNotFound.Error()},
+			})
+		default:
+			logrus.Error("[error while using upload member photo service] ", err)
+			c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, &CreateMemberAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) RevertMember(c *gin.Context) {
+	var errorList []string
+
+	payload, err := utils.GetPayloadFromContext(c)
+	if err != nil {
+		logrus.Error("[error while extracting context] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	// Input Validation
+	memberID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	revisionID, err := strconv.Atoi(c.Param("revision_id"))
+	if err != nil {
+		errorList = append(errorList, "revision_id should be a number")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &CreateMemberAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Revert(c, memberID, revisionID, payload.UserID)
+	if err != nil {
+		switch errors.Cause(err) {
+		case ErrMemberNotFound, revision.ErrRevisionNotFound:
+			c.JSON(http.StatusNotFound, &CreateMemberAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{errors.Cause(err).Error()},
+			})
+		default:
+			logrus.Error("[error while using revert member service] ", err)
+			c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, &CreateMemberAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) ReorderMember(c *gin.Context) {
+	var requestBody ReorderAPIRequest
+
+	// Input Validation
+	bindErr := c.ShouldBindJSON(&requestBody)
+	errorList := utils.ValidateReorderIDs(bindErr, requestBody.IDs)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ReorderAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	err := h.service.Reorder(requestBody.IDs)
+	if err != nil {
+		if errors.Cause(err) == ErrMemberNotFound {
+			c.JSON(http.StatusNotFound, &ReorderAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrMemberNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using reorder member service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &ReorderAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+	})
+}
+
+func bindMemberRequest(c *gin.Context, requestBody *CreateMemberAPIRequest) []string {
+	var errorList []string
+
+	err := c.ShouldBindJSON(requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	if requestBody.Name == "" {
+		errorList = append(errorList, "name is required")
+	}
+
+	if len(requestBody.Name) > 128 {
+		errorList = append(errorList, "name should be less than 128 characters")
+	}
+
+	return errorList
+}
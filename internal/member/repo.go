@@ -0,0 +1,264 @@
+package member
+
+import (
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/slugs"
+)
+
+const memberColumns = "id, name, slug, photo, position, featured"
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+type Repo interface {
+	List(q *listquery.Query) (*ListMember, error)
+	GetByID(ID int) (*Member, error)
+	GetBySlug(slug string) (*Member, error)
+	GetSlugRedirect(oldSlug string) (string, error)
+	SlugExists(slug string, excludeID int) (bool, error)
+	Create(name string, photo *string, featured bool, slug string, record func(tx *sqlx.Tx, member *Member) error) (*Member, error)
+	Update(ID int, name string, photo *string, featured bool, slug string, record func(tx *sqlx.Tx, member *Member) error) (*Member, error)
+	UpdatePhoto(ID int, photo string, record func(tx *sqlx.Tx, member *Member) error) (*Member, error)
+	Reorder(IDs []int) error
+	ListProjects(memberID int, publishedOnly bool) ([]Project, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func (r repo) List(q *listquery.Query) (*ListMember, error) {
+	members := ListMember{Members: []Member{}}
+
+	query, args := q.SelectSQL(memberColumns, "jastip_members", "deleted_at IS NULL")
+	err := r.db.Select(&members.Members, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	members.Page, err = q.Paginate(&members.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	members.Total, err = q.Total(r.db, "jastip_members", "deleted_at IS NULL")
+	if err != nil {
+		return nil, err
+	}
+
+	members.Count = len(members.Members)
+
+	return &members, nil
+}
+
+func (r repo) GetByID(ID int) (*Member, error) {
+	var member Member
+	err := r.db.Get(&member, "SELECT "+memberColumns+" FROM jastip_members WHERE id=$1 AND deleted_at IS NULL", ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrMemberNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &member, nil
+}
+
+func (r repo) GetBySlug(slug string) (*Member, error) {
+	var member Member
+	err := r.db.Get(&member, "SELECT "+memberColumns+" FROM jastip_members WHERE slug=$1 AND deleted_at IS NULL", slug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrMemberNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &member, nil
+}
+
+// GetSlugRedirect returns the current slug of the member that used to be reachable through oldSlug
+func (r repo) GetSlugRedirect(oldSlug string) (string, error) {
+	slug, err := slugs.Members.Redirect(r.db, oldSlug)
+	if errors.Cause(err) == slugs.ErrSlugNotFound {
+		return "", errors.Wrap(ErrMemberNotFound, err.Error())
+	}
+
+	return slug, err
+}
+
+// SlugExists checks whether slug is used, or kept as a redirect, by any member other than excludeID
+func (r repo) SlugExists(slug string, excludeID int) (bool, error) {
+	return slugs.Members.Exists(r.db, slug, excludeID)
+}
+
+// Create puts the new member at the end of the manual ordering. record is called with the new
+// member before committing, whatever it writes in tx is saved together with the member.
+func (r repo) Create(name string, photo *string, featured bool, slug string, record func(tx *sqlx.Tx, member *Member) error) (*Member, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	var member Member
+	err = tx.Get(&member, `
+		INSERT INTO jastip_members (name, photo, featured, slug, position)
+		VALUES ($1, $2, $3, $4, (SELECT COALESCE(MAX(position), 0) + 1 FROM jastip_members))
+		RETURNING `+memberColumns, name, photo, featured, slug)
+	if err != nil {
+		if slugs.Members.IsTaken(err) {
+			return nil, errors.Wrap(slugs.ErrSlugTaken, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = record(tx, &member)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &member, nil
+}
+
+// Update saves the member and, when the slug changes, keeps the old one in slug_history as a redirect
+func (r repo) Update(ID int, name string, photo *string, featured bool, slug string, record func(tx *sqlx.Tx, member *Member) error) (*Member, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	var oldSlug string
+	err = tx.Get(&oldSlug, "SELECT slug FROM jastip_members WHERE id=$1 AND deleted_at IS NULL FOR UPDATE", ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrMemberNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	var member Member
+	err = tx.Get(&member, "UPDATE jastip_members SET name=$1, photo=$2, featured=$3, slug=$4 WHERE id=$5 RETURNING "+memberColumns, name, photo, featured, slug, ID)
+	if err != nil {
+		if slugs.Members.IsTaken(err) {
+			return nil, errors.Wrap(slugs.ErrSlugTaken, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = slugs.Members.Move(tx, ID, oldSlug, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	err = record(tx, &member)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &member, nil
+}
+
+func (r repo) UpdatePhoto(ID int, photo string, record func(tx *sqlx.Tx, member *Member) error) (*Member, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	var member Member
+	err = tx.Get(&member, "UPDATE jastip_members SET photo=$1 WHERE id=$2 AND deleted_at IS NULL RETURNING "+memberColumns, photo, ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrMemberNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = record(tx, &member)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &member, nil
+}
+
+func (r repo) ListProjects(memberID int, publishedOnly bool) ([]Project, error) {
+	projects := []Project{}
+	err := r.db.Select(&projects, `
+		SELECT p.id, p.name, p.slug, p.client_name, pm.role
+		FROM project_members pm
+		JOIN projects p ON p.id = pm.project_id
+		WHERE pm.jastip_member_id=$1 AND p.deleted_at IS NULL AND (NOT $2 OR p.status = 'published')
+		ORDER BY p.position, p.id`, memberID, publishedOnly)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return projects, nil
+}
+
+// Reorder moves IDs, in the given order, to the top of the manual ordering.
+// Members not listed keep their relative order after them.
+func (r repo) Reorder(IDs []int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	var found int
+	err = tx.Get(&found, "SELECT COUNT(*) FROM jastip_members WHERE id = ANY($1) AND deleted_at IS NULL", pq.Array(IDs))
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	if found != len(IDs) {
+		return errors.Wrap(ErrMemberNotFound, "some ids don't belong to a member")
+	}
+
+	_, err = tx.Exec(`
+		WITH ordered AS (
+			SELECT m.id, ROW_NUMBER() OVER (ORDER BY o.ord NULLS LAST, m.position, m.id) AS position
+			FROM jastip_members m
+			LEFT JOIN unnest($1::int[]) WITH ORDINALITY AS o(id, ord) ON o.id = m.id
+			WHERE m.deleted_at IS NULL
+		)
+		UPDATE jastip_members m SET position = ordered.position
+		FROM ordered
+		WHERE m.id = ordered.id`, pq.Array(IDs))
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,259 @@
+package member
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/media"
+	"github.com/rafimuhammad01/portofolio-api/internal/revision"
+	"github.com/rafimuhammad01/portofolio-api/internal/slugs"
+	"github.com/rafimuhammad01/portofolio-api/internal/storage"
+	"github.com/sirupsen/logrus"
+	"strings"
+)
+
+func NewService(repo Repo, store storage.BlobStore, mediaService media.Service, revisionService revision.Service) Service {
+	return &service{
+		repo:            repo,
+		store:           store,
+		mediaService:    mediaService,
+		revisionService: revisionService,
+	}
+}
+
+type Service interface {
+	List(q *listquery.Query) (*ListMember, error)
+	Get(ID int, preview bool) (*Detail, error)
+	GetBySlug(slug string, preview bool) (*Detail, error)
+	ResolveSlug(oldSlug string) (string, error)
+	Create(name string, photo *string, featured bool, editorID int) (*Member, error)
+	Update(ctx context.Context, ID int, name string, photo *string, featured bool, editorID int) (*Member, error)
+	UploadPhoto(ctx context.Context, ID int, image []byte, editorID int) (*Member, error)
+	Revert(ctx context.Context, ID, revisionID, editorID int) (*Member, error)
+	Reorder(IDs []int) error
+}
+
+type service struct {
+	repo            Repo
+	store           storage.BlobStore
+	mediaService    media.Service
+	revisionService revision.Service
+}
+
+func (s service) List(q *listquery.Query) (*ListMember, error) {
+	members, err := s.repo.List(q)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.attachImages(members.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (s service) Get(ID int, preview bool) (*Detail, error) {
+	member, err := s.repo.GetByID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.detail(member, preview)
+}
+
+func (s service) GetBySlug(slug string, preview bool) (*Detail, error) {
+	member, err := s.repo.GetBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.detail(member, preview)
+}
+
+// ResolveSlug finds the current slug of a member that was renamed away from oldSlug
+func (s service) ResolveSlug(oldSlug string) (string, error) {
+	return s.repo.GetSlugRedirect(oldSlug)
+}
+
+func (s service) Create(name string, photo *string, featured bool, editorID int) (*Member, error) {
+	var member *Member
+	err := s.saveWithSlug(name, 0, func(slug string) (err error) {
+		member, err = s.repo.Create(name, photo, featured, slug, func(tx *sqlx.Tx, created *Member) error {
+			return s.revisionService.Record(tx, revision.TypeMember, created.ID, editorID, nil, created)
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (s service) Update(ctx context.Context, ID int, name string, photo *string, featured bool, editorID int) (*Member, error) {
+	current, err := s.repo.GetByID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var member *Member
+	save := func(slug string) (err error) {
+		member, err = s.repo.Update(ID, name, photo, featured, slug, s.record(current, editorID))
+		return err
+	}
+
+	// Only generate a new slug when the name actually changes
+	if current.Name != name {
+		err = s.saveWithSlug(name, ID, save)
+	} else {
+		err = save(current.Slug)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.releasePhoto(ctx, current.Photo, member.Photo)
+
+	return member, nil
+}
+
+// UploadPhoto stores the image and records where it's served from as the member photo
+func (s service) UploadPhoto(ctx context.Context, ID int, image []byte, editorID int) (*Member, error) {
+	current, err := s.repo.GetByID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := storage.SaveImage(ctx, s.store, image)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.repo.UpdatePhoto(ID, stored.URL, s.record(current, editorID))
+	if err != nil {
+		storage.DiscardImage(ctx, s.store, stored)
+		return nil, err
+	}
+
+	s.releasePhoto(ctx, current.Photo, member.Photo)
+
+	return member, nil
+}
+
+// Revert saves the member as it was in an earlier revision, which is recorded as a new revision
+func (s service) Revert(ctx context.Context, ID, revisionID, editorID int) (*Member, error) {
+	rev, err := s.revisionService.Get(revision.TypeMember, ID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Member
+	err = rev.Decode(&snapshot)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	// The photo of an older revision is deleted once no member uses it, the current photo is kept then
+	if snapshot.Photo != nil && !s.photoExists(ctx, *snapshot.Photo) {
+		current, err := s.repo.GetByID(ID)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Photo = current.Photo
+	}
+
+	return s.Update(ctx, ID, snapshot.Name, snapshot.Photo, snapshot.Featured, editorID)
+}
+
+// releasePhoto deletes the stored file of a replaced photo. The member is already saved by then,
+// so a failure is only logged and the media processor deletes the file on a later run.
+func (s service) releasePhoto(ctx context.Context, previous, photo *string) {
+	if previous == nil || (photo != nil && *photo == *previous) {
+		return
+	}
+
+	err := s.mediaService.Release(ctx, *previous)
+	if err != nil {
+		logrus.Error("[error while releasing replaced member photo] ", err)
+	}
+}
+
+// photoExists tells whether a photo URL can still be served, pasted URLs hosted elsewhere are assumed to be
+func (s service) photoExists(ctx context.Context, photo string) bool {
+	prefix := s.store.URL("")
+	if !strings.HasPrefix(photo, prefix) {
+		return true
+	}
+
+	blob, err := s.store.Get(ctx, strings.TrimPrefix(photo, prefix))
+	if err != nil {
+		return errors.Cause(err) != storage.ErrBlobNotFound
+	}
+	blob.Close()
+
+	return true
+}
+
+func (s service) Reorder(IDs []int) error {
+	return s.repo.Reorder(IDs)
+}
+
+// saveWithSlug saves the member through save under a unique slug for name, picking another one when a concurrent save takes it first
+func (s service) saveWithSlug(name string, memberID int, save func(slug string) error) error {
+	return slugs.Save(name, slugs.Members.EntityType, func(slug string) (bool, error) {
+		return s.repo.SlugExists(slug, memberID)
+	}, save)
+}
+
+// record returns the hook that saves a revision of an update to current in the same transaction
+func (s service) record(current *Member, editorID int) func(tx *sqlx.Tx, member *Member) error {
+	return func(tx *sqlx.Tx, member *Member) error {
+		return s.revisionService.Record(tx, revision.TypeMember, member.ID, editorID, current, member)
+	}
+}
+
+// detail credits only published projects unless an authenticated editor asked for a preview
+func (s service) detail(member *Member, preview bool) (*Detail, error) {
+	projects, err := s.repo.ListProjects(member.ID, !preview)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []Member{*member}
+	err = s.attachImages(members)
+	if err != nil {
+		return nil, err
+	}
+	member = &members[0]
+
+	return &Detail{
+		Member:   *member,
+		Projects: projects,
+	}, nil
+}
+
+// attachImages fills in the processed variants of member photos
+func (s service) attachImages(members []Member) error {
+	var sources []string
+	for _, member := range members {
+		if member.Photo != nil {
+			sources = append(sources, *member.Photo)
+		}
+	}
+
+	images, err := s.mediaService.Lookup(sources)
+	if err != nil {
+		return err
+	}
+
+	for i := range members {
+		if members[i].Photo != nil {
+			members[i].Image = images[*members[i].Photo]
+		}
+	}
+
+	return nil
+}
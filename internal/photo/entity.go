@@ -0,0 +1,50 @@
+package photo
+
+import (
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/media"
+)
+
+// Photo entity represent project_photos table in database
+type Photo struct {
+	ID          int     `json:"id" db:"id"`
+	Photo       *string `json:"photo" db:"photo"`
+	Description *string `json:"description" db:"description"`
+	ProjectID   *int    `json:"project_id" db:"project_id"`
+	// Image holds the variants of an uploaded photo once they are processed
+	Image *media.Image `json:"image" db:"-"`
+}
+
+type ListPhoto struct {
+	Photos []Photo `json:"photos"`
+	Count  int     `json:"count"`
+	listquery.Page
+}
+
+// listConfig whitelists sort and filter parameters for List
+var listConfig = listquery.Config{
+	Fields: map[string]listquery.Field{
+		"id":         {Column: "id", Type: listquery.TypeInt, Sortable: true, Filterable: true},
+		"project_id": {Column: "project_id", Type: listquery.TypeInt, Filterable: true},
+	},
+	IDField:      "id",
+	DefaultSort:  "id",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// ListPhotoAPIResponse API response for List
+type ListPhotoAPIResponse struct {
+	Status  int        `json:"status"`
+	Message string     `json:"message"`
+	Data    *ListPhoto `json:"data,omitempty"`
+	Errors  []string   `json:"errors,omitempty"`
+}
+
+// UploadPhotoAPIResponse API response for Upload
+type UploadPhotoAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Photo   `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
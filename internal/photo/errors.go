@@ -0,0 +1,8 @@
+package photo
+
+import "github.com/pkg/errors"
+
+var (
+	ErrProjectNotFound = errors.New("project not found")
+	ErrInternalServer  = errors.New("internal server error")
+)
@@ -0,0 +1,120 @@
+package photo
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/storage"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) GetAllPhoto(c *gin.Context) {
+	// Input Validation
+	q, errorList := listquery.Parse(c.Request.URL.Query(), listConfig)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ListPhotoAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.List(q)
+	if err != nil {
+		logrus.Error("[error while using list photo service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &ListPhotoAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) UploadPhoto(c *gin.Context) {
+	var errorList []string
+
+	// Input Validation
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	image, err := storage.ReadUpload(c, "photo", utils.GetMaxUploadSize())
+	if err != nil {
+		switch errors.Cause(err) {
+		case storage.ErrFileTooLarge:
+			c.JSON(http.StatusRequestEntityTooLarge, &UploadPhotoAPIResponse{
+				Status:  http.StatusRequestEntityTooLarge,
+				Message: "request entity too large",
+				Errors:  []string{storage.ErrFileTooLarge.Error()},
+			})
+			return
+		case storage.ErrMissingFile:
+			errorList = append(errorList, "photo is required")
+		default:
+			logrus.Error("[error while reading uploaded photo] ", err)
+			c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+			return
+		}
+	}
+
+	var description *string
+	if value := strings.TrimSpace(c.PostForm("description")); value != "" {
+		description = &value
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &UploadPhotoAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Upload(c, projectID, description, image)
+	if err != nil {
+		switch errors.Cause(err) {
+		case storage.ErrUnsupportedFileType:
+			c.JSON(http.StatusUnsupportedMediaType, &UploadPhotoAPIResponse{
+				Status:  http.StatusUnsupportedMediaType,
+				Message: "unsupported media type",
+				Errors:  []string{storage.ErrUnsupportedFileType.Error()},
+			})
+		case ErrProjectNotFound:
+			c.JSON(http.StatusNotFound, &UploadPhotoAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrProjectNotFound.Error()},
+			})
+		default:
+			logrus.Error("[error while using upload photo service] ", err)
+			c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, &UploadPhotoAPIResponse{
+		Status:  http.StatusCreated,
+		Message: "success",
+		Data:    res,
+	})
+}
@@ -0,0 +1,73 @@
+package photo
+
+import (
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+)
+
+// photosFrom only lets photos of published projects that aren't in trash through
+const photosFrom = `(
+	SELECT ph.*
+	FROM project_photos ph
+	JOIN projects p ON p.id = ph.project_id AND p.deleted_at IS NULL AND p.status = 'published'
+	WHERE ph.deleted_at IS NULL
+) photos_view`
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+type Repo interface {
+	List(q *listquery.Query) (*ListPhoto, error)
+	Create(projectID int, photo string, description *string) (*Photo, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func (r repo) List(q *listquery.Query) (*ListPhoto, error) {
+	photos := ListPhoto{Photos: []Photo{}}
+
+	query, args := q.SelectSQL("id, photo, description, project_id", photosFrom, "")
+	err := r.db.Select(&photos.Photos, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	photos.Page, err = q.Paginate(&photos.Photos)
+	if err != nil {
+		return nil, err
+	}
+
+	photos.Total, err = q.Total(r.db, photosFrom, "")
+	if err != nil {
+		return nil, err
+	}
+
+	photos.Count = len(photos.Photos)
+
+	return &photos, nil
+}
+
+// Create adds a photo to a project, trashed projects can't get new photos
+func (r repo) Create(projectID int, photo string, description *string) (*Photo, error) {
+	var p Photo
+	err := r.db.Get(&p, `
+		INSERT INTO project_photos (photo, description, project_id)
+		SELECT $1, $2, id FROM projects WHERE id=$3 AND deleted_at IS NULL
+		RETURNING id, photo, description, project_id`, photo, description, projectID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrProjectNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &p, nil
+}
@@ -0,0 +1,70 @@
+package photo
+
+import (
+	"context"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/media"
+	"github.com/rafimuhammad01/portofolio-api/internal/storage"
+)
+
+func NewService(repo Repo, store storage.BlobStore, mediaService media.Service) Service {
+	return &service{
+		repo:         repo,
+		store:        store,
+		mediaService: mediaService,
+	}
+}
+
+type Service interface {
+	List(q *listquery.Query) (*ListPhoto, error)
+	Upload(ctx context.Context, projectID int, description *string, image []byte) (*Photo, error)
+}
+
+type service struct {
+	repo         Repo
+	store        storage.BlobStore
+	mediaService media.Service
+}
+
+func (s service) List(q *listquery.Query) (*ListPhoto, error) {
+	photos, err := s.repo.List(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []string
+	for _, photo := range photos.Photos {
+		if photo.Photo != nil {
+			sources = append(sources, *photo.Photo)
+		}
+	}
+
+	images, err := s.mediaService.Lookup(sources)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range photos.Photos {
+		if photos.Photos[i].Photo != nil {
+			photos.Photos[i].Image = images[*photos.Photos[i].Photo]
+		}
+	}
+
+	return photos, nil
+}
+
+// Upload stores the image and records where it's served from as a new project photo
+func (s service) Upload(ctx context.Context, projectID int, description *string, image []byte) (*Photo, error) {
+	stored, err := storage.SaveImage(ctx, s.store, image)
+	if err != nil {
+		return nil, err
+	}
+
+	photo, err := s.repo.Create(projectID, stored.URL, description)
+	if err != nil {
+		storage.DiscardImage(ctx, s.store, stored)
+		return nil, err
+	}
+
+	return photo, nil
+}
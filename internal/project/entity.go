@@ -0,0 +1,178 @@
+package project
+
+import (
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"time"
+)
+
+// Project lifecycle status. A draft with publish_at set is scheduled
+// and gets published by the scheduler once publish_at has passed.
+const (
+	StatusDraft     = "draft"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+)
+
+// Project entity represent projects table in database
+type Project struct {
+	ID          int        `json:"id" db:"id"`
+	Name        string     `json:"name" db:"name"`
+	Slug        string     `json:"slug" db:"slug"`
+	ClientName  string     `json:"client_name" db:"client_name"`
+	Description *string    `json:"description" db:"description"`
+	Status      string     `json:"status" db:"status"`
+	PublishAt   *time.Time `json:"publish_at" db:"publish_at"`
+	Position    int        `json:"position" db:"position"`
+	Featured    bool       `json:"featured" db:"featured"`
+	// Reactions are only filled in for lists and details, they're counted apart from the project itself
+	Reactions map[string]int64 `json:"reactions,omitempty" db:"-"`
+	// DescriptionHTML and DescriptionExcerpt are rendered from the Markdown in Description
+	DescriptionHTML    *string `json:"description_html,omitempty" db:"-"`
+	DescriptionExcerpt *string `json:"description_excerpt,omitempty" db:"-"`
+}
+
+// TeamMember is a jastip member credited on a project through project_members table
+type TeamMember struct {
+	MemberID int     `json:"member_id" db:"jastip_member_id"`
+	Name     string  `json:"name" db:"name"`
+	Slug     string  `json:"slug" db:"slug"`
+	Photo    *string `json:"photo" db:"photo"`
+	Role     string  `json:"role" db:"role"`
+}
+
+// Tag is a technology tag assigned to a project through project_tags table
+type Tag struct {
+	ID   int    `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+	Slug string `json:"slug" db:"slug"`
+}
+
+// Testimonial is an approved client testimonial about a project
+type Testimonial struct {
+	ID          int     `json:"id" db:"id"`
+	Quote       string  `json:"quote" db:"quote"`
+	AuthorName  string  `json:"author_name" db:"author_name"`
+	AuthorTitle *string `json:"author_title" db:"author_title"`
+	Rating      int     `json:"rating" db:"rating"`
+}
+
+// Link is an external link of a project, like its live demo or repository
+type Link struct {
+	ID    int     `json:"id" db:"id"`
+	Type  string  `json:"type" db:"type"`
+	Label *string `json:"label" db:"label"`
+	URL   string  `json:"url" db:"url"`
+}
+
+// TagFilter limits a project list to projects tagged with any, or all when MatchAll is set, of the tag slugs
+type TagFilter struct {
+	Slugs    []string
+	MatchAll bool
+}
+
+// Detail is a project along with the team that worked on it, where to find it and what the client said about it
+type Detail struct {
+	Project
+	Tags         []Tag         `json:"tags"`
+	Links        []Link        `json:"links"`
+	Team         []TeamMember  `json:"team"`
+	Testimonials []Testimonial `json:"testimonials"`
+	Locales      []string      `json:"-"`
+}
+
+type ListProject struct {
+	Projects []Project `json:"projects"`
+	Count    int       `json:"count"`
+	listquery.Page
+	// Locales the projects were served in, for the Content-Language header
+	Locales []string `json:"-"`
+}
+
+// listConfig whitelists sort and filter parameters for List
+var listConfig = listquery.Config{
+	Fields: map[string]listquery.Field{
+		"id":          {Column: "id", Type: listquery.TypeInt, Sortable: true, Filterable: true},
+		"name":        {Column: "name", Sortable: true, Filterable: true},
+		"client_name": {Column: "client_name", Sortable: true, Filterable: true},
+		"slug":        {Column: "slug", Filterable: true},
+		"status":      {Column: "status", Filterable: true},
+		"position":    {Column: "position", Type: listquery.TypeInt, Sortable: true},
+		"featured":    {Column: "featured", Type: listquery.TypeBool, Sortable: true, Filterable: true},
+	},
+	IDField:      "id",
+	DefaultSort:  "position",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// ListProjectAPIResponse API response for List
+type ListProjectAPIResponse struct {
+	Status  int          `json:"status"`
+	Message string       `json:"message"`
+	Data    *ListProject `json:"data,omitempty"`
+	Errors  []string     `json:"errors,omitempty"`
+}
+
+type GetProjectByIDAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Detail  `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// CreateProjectAPIRequest create and update project request body from client
+type CreateProjectAPIRequest struct {
+	Name        string  `json:"name"`
+	ClientName  string  `json:"client_name"`
+	Description *string `json:"description"`
+	Featured    bool    `json:"featured"`
+}
+
+type CreateProjectAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Project `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// ReorderAPIRequest reorder request body from client, ids are ordered from first to last
+type ReorderAPIRequest struct {
+	IDs []int `json:"ids"`
+}
+
+type ReorderAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// UpdateStatusAPIRequest update project status request body from client
+type UpdateStatusAPIRequest struct {
+	Status    string     `json:"status"`
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+// AssignTagAPIRequest assign tag request body from client
+type AssignTagAPIRequest struct {
+	TagID int `json:"tag_id"`
+}
+
+type AssignTagAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    []Tag    `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// AssignMemberAPIRequest assign member request body from client
+type AssignMemberAPIRequest struct {
+	MemberID int    `json:"member_id"`
+	Role     string `json:"role"`
+}
+
+type AssignMemberAPIResponse struct {
+	Status  int         `json:"status"`
+	Message string      `json:"message"`
+	Data    *TeamMember `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
@@ -0,0 +1,12 @@
+package project
+
+import "github.com/pkg/errors"
+
+var (
+	ErrProjectNotFound   = errors.New("project not found")
+	ErrMemberNotFound    = errors.New("member not found")
+	ErrMemberNotAssigned = errors.New("member is not assigned to this project")
+	ErrTagNotFound       = errors.New("tag not found")
+	ErrTagNotAssigned    = errors.New("tag is not assigned to this project")
+	ErrInternalServer    = errors.New("internal server error")
+)
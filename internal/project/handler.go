@@ -0,0 +1,605 @@
+package project
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/revision"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) GetAllProject(c *gin.Context) {
+	preview, err := utils.IsPreview(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &ListProjectAPIResponse{
+			Status:  http.StatusUnauthorized,
+			Message: "unauthorized",
+			Errors:  []string{errors.Cause(err).Error()},
+		})
+		return
+	}
+
+	locale := utils.ResolveLocale(c)
+
+	// Input Validation
+	q, errorList := listquery.Parse(c.Request.URL.Query(), listConfig)
+
+	// Tags given more than once, or spelled differently, count once so match all can still be satisfied
+	var tagFilter TagFilter
+	seenTags := map[string]bool{}
+	for _, tag := range c.QueryArray("tag") {
+		slug := utils.Slugify(tag)
+		if !seenTags[slug] {
+			seenTags[slug] = true
+			tagFilter.Slugs = append(tagFilter.Slugs, slug)
+		}
+	}
+
+	switch c.DefaultQuery("tag_match", "any") {
+	case "any":
+	case "all":
+		tagFilter.MatchAll = true
+	default:
+		errorList = append(errorList, "tag_match should be either any or all")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ListProjectAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.List(q, preview, tagFilter, locale)
+	if err != nil {
+		logrus.Error("[error while using list project service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	utils.SetContentLanguage(c, res.Locales)
+	c.JSON(http.StatusOK, &ListProjectAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+// GetProjectByID accepts either the numeric id or the slug of a project.
+// Old slugs of renamed projects are redirected to the current one.
+func (h *Handler) GetProjectByID(c *gin.Context) {
+	var res *Detail
+
+	preview, err := utils.IsPreview(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, GetProjectByIDAPIResponse{
+			Status:  http.StatusUnauthorized,
+			Message: "unauthorized",
+			Errors:  []string{errors.Cause(err).Error()},
+		})
+		return
+	}
+
+	locale := utils.ResolveLocale(c)
+
+	slug := c.Param("id")
+	projectID, atoiErr := strconv.Atoi(slug)
+	if atoiErr == nil {
+		res, err = h.service.Get(projectID, preview, locale)
+	} else {
+		res, err = h.service.GetBySlug(slug, preview, locale)
+		if errors.Cause(err) == ErrProjectNotFound {
+			newSlug, resolveErr := h.service.ResolveSlug(slug)
+			if resolveErr == nil {
+				c.Redirect(http.StatusMovedPermanently, utils.SlugRedirectLocation(c.Request.URL, slug, newSlug))
+				return
+			}
+			if errors.Cause(resolveErr) != ErrProjectNotFound {
+				err = resolveErr
+			}
+		}
+	}
+
+	if err != nil {
+		if errors.Cause(err) == ErrProjectNotFound {
+			c.JSON(http.StatusNotFound, GetProjectByIDAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrProjectNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using get project service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	utils.SetContentLanguage(c, res.Locales)
+	c.JSON(http.StatusOK, GetProjectByIDAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) CreateProject(c *gin.Context) {
+	var requestBody CreateProjectAPIRequest
+
+	payload, err := utils.GetPayloadFromContext(c)
+	if err != nil {
+		logrus.Error("[error while extracting context] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	// Input Validation
+	errorList := bindProjectRequest(c, &requestBody)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &CreateProjectAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Create(requestBody.Name, requestBody.ClientName, requestBody.Description, requestBody.Featured, payload.UserID)
+	if err != nil {
+		logrus.Error("[error while using create project service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusCreated, &CreateProjectAPIResponse{
+		Status:  http.StatusCreated,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) UpdateProject(c *gin.Context) {
+	var requestBody CreateProjectAPIRequest
+
+	payload, err := utils.GetPayloadFromContext(c)
+	if err != nil {
+		logrus.Error("[error while extracting context] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	// Input Validation
+	projectID, err := strconv.Atoi(c.Param("id"))
+	errorList := bindProjectRequest(c, &requestBody)
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &CreateProjectAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Update(projectID, requestBody.Name, requestBody.ClientName, requestBody.Description, requestBody.Featured, payload.UserID)
+	if err != nil {
+		if errors.Cause(err) == ErrProjectNotFound {
+			c.JSON(http.StatusNotFound, &CreateProjectAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrProjectNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using update project service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &CreateProjectAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) RevertProject(c *gin.Context) {
+	var errorList []string
+
+	payload, err := utils.GetPayloadFromContext(c)
+	if err != nil {
+		logrus.Error("[error while extracting context] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	// Input Validation
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	revisionID, err := strconv.Atoi(c.Param("revision_id"))
+	if err != nil {
+		errorList = append(errorList, "revision_id should be a number")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &CreateProjectAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Revert(projectID, revisionID, payload.UserID)
+	if err != nil {
+		switch errors.Cause(err) {
+		case ErrProjectNotFound, revision.ErrRevisionNotFound:
+			c.JSON(http.StatusNotFound, &CreateProjectAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{errors.Cause(err).Error()},
+			})
+		default:
+			logrus.Error("[error while using revert project service] ", err)
+			c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, &CreateProjectAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) ReorderProject(c *gin.Context) {
+	var requestBody ReorderAPIRequest
+
+	// Input Validation
+	bindErr := c.ShouldBindJSON(&requestBody)
+	errorList := utils.ValidateReorderIDs(bindErr, requestBody.IDs)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ReorderAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	err := h.service.Reorder(requestBody.IDs)
+	if err != nil {
+		if errors.Cause(err) == ErrProjectNotFound {
+			c.JSON(http.StatusNotFound, &ReorderAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrProjectNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using reorder project service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &ReorderAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+	})
+}
+
+func (h *Handler) UpdateProjectStatus(c *gin.Context) {
+	var (
+		errorList   []string
+		requestBody UpdateStatusAPIRequest
+	)
+
+	payload, err := utils.GetPayloadFromContext(c)
+	if err != nil {
+		logrus.Error("[error while extracting context] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	// Input Validation
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	err = c.ShouldBindJSON(&requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	if requestBody.Status != StatusDraft && requestBody.Status != StatusPublished && requestBody.Status != StatusArchived {
+		errorList = append(errorList, "status should be one of draft, published or archived")
+	}
+
+	if requestBody.PublishAt != nil && requestBody.Status != StatusDraft {
+		errorList = append(errorList, "publish_at can only be set on a draft to schedule it")
+	}
+
+	if requestBody.PublishAt != nil && !requestBody.PublishAt.After(time.Now()) {
+		errorList = append(errorList, "publish_at should be in the future")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &CreateProjectAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.UpdateStatus(projectID, requestBody.Status, requestBody.PublishAt, payload.UserID)
+	if err != nil {
+		if errors.Cause(err) == ErrProjectNotFound {
+			c.JSON(http.StatusNotFound, &CreateProjectAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrProjectNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using update project status service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &CreateProjectAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) AssignMember(c *gin.Context) {
+	var (
+		errorList   []string
+		requestBody AssignMemberAPIRequest
+	)
+
+	// Input Validation
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	err = c.ShouldBindJSON(&requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	if requestBody.MemberID == 0 {
+		errorList = append(errorList, "member_id is required")
+	}
+
+	if requestBody.Role == "" {
+		errorList = append(errorList, "role is required")
+	}
+
+	if len(requestBody.Role) > 128 {
+		errorList = append(errorList, "role should be less than 128 characters")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &AssignMemberAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.AssignMember(projectID, requestBody.MemberID, requestBody.Role)
+	if err != nil {
+		if errors.Cause(err) == ErrProjectNotFound || errors.Cause(err) == ErrMemberNotFound {
+			c.JSON(http.StatusNotFound, &AssignMemberAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{errors.Cause(err).Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using assign member service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusCreated, &AssignMemberAPIResponse{
+		Status:  http.StatusCreated,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) UnassignMember(c *gin.Context) {
+	var errorList []string
+
+	// Input Validation
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	memberID, err := strconv.Atoi(c.Param("member_id"))
+	if err != nil {
+		errorList = append(errorList, "member_id should be a number")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &AssignMemberAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	err = h.service.UnassignMember(projectID, memberID)
+	if err != nil {
+		if errors.Cause(err) == ErrMemberNotAssigned {
+			c.JSON(http.StatusNotFound, &AssignMemberAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrMemberNotAssigned.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using unassign member service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &AssignMemberAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+	})
+}
+
+func bindProjectRequest(c *gin.Context, requestBody *CreateProjectAPIRequest) []string {
+	var errorList []string
+
+	err := c.ShouldBindJSON(requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	if requestBody.Name == "" {
+		errorList = append(errorList, "name is required")
+	}
+
+	if requestBody.ClientName == "" {
+		errorList = append(errorList, "client_name is required")
+	}
+
+	if len(requestBody.Name) > 128 {
+		errorList = append(errorList, "name should be less than 128 characters")
+	}
+
+	if len(requestBody.ClientName) > 128 {
+		errorList = append(errorList, "client_name should be less than 128 characters")
+	}
+
+	return errorList
+}
+
+func (h *Handler) AssignTag(c *gin.Context) {
+	var (
+		errorList   []string
+		requestBody AssignTagAPIRequest
+	)
+
+	// Input Validation
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	err = c.ShouldBindJSON(&requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	if requestBody.TagID == 0 {
+		errorList = append(errorList, "tag_id is required")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &AssignTagAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.AssignTag(projectID, requestBody.TagID)
+	if err != nil {
+		if errors.Cause(err) == ErrProjectNotFound || errors.Cause(err) == ErrTagNotFound {
+			c.JSON(http.StatusNotFound, &AssignTagAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{errors.Cause(err).Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using assign tag service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusCreated, &AssignTagAPIResponse{
+		Status:  http.StatusCreated,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) UnassignTag(c *gin.Context) {
+	var errorList []string
+
+	// Input Validation
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	tagID, err := strconv.Atoi(c.Param("tag_id"))
+	if err != nil {
+		errorList = append(errorList, "tag_id should be a number")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &AssignTagAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	err = h.service.UnassignTag(projectID, tagID)
+	if err != nil {
+		if errors.Cause(err) == ErrTagNotAssigned {
+			c.JSON(http.StatusNotFound, &AssignTagAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrTagNotAssigned.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using unassign tag service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &AssignTagAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+	})
+}
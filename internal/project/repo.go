@@ -0,0 +1,475 @@
+package project
+
+import (
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/slugs"
+	"time"
+)
+
+const (
+	// foreignKeyViolation is the PostgreSQL error code for foreign_key_violation
+	foreignKeyViolation = "23503"
+
+	projectColumns = "id, name, slug, client_name, description, status, publish_at, position, featured"
+
+	// notDeletedCondition hides projects that were moved to trash
+	notDeletedCondition = "deleted_at IS NULL"
+
+	// publishedCondition limits queries to projects visible to the public
+	publishedCondition = notDeletedCondition + " AND status = 'published'"
+)
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+type Repo interface {
+	List(q *listquery.Query, publishedOnly bool, tagFilter TagFilter) (*ListProject, error)
+	GetByID(ID int) (*Project, error)
+	GetBySlug(slug string) (*Project, error)
+	GetSlugRedirect(oldSlug string) (string, error)
+	SlugExists(slug string, excludeID int) (bool, error)
+	Create(name, clientName string, description *string, featured bool, slug string, record func(tx *sqlx.Tx, project *Project) error) (*Project, error)
+	Update(ID int, name, clientName string, description *string, featured bool, slug string, record func(tx *sqlx.Tx, project *Project) error) (*Project, error)
+	Reorder(IDs []int) error
+	ListTeam(projectID int) ([]TeamMember, error)
+	AssignMember(projectID, memberID int, role string) (*TeamMember, error)
+	UnassignMember(projectID, memberID int) error
+	ListTags(projectID int) ([]Tag, error)
+	ListTestimonials(projectID int) ([]Testimonial, error)
+	ListLinks(projectID int, hideBroken bool) ([]Link, error)
+	ListReactions(projectIDs []int) (map[int]map[string]int64, error)
+	AssignTag(projectID, tagID int) error
+	UnassignTag(projectID, tagID int) error
+	UpdateStatus(ID int, status string, publishAt *time.Time, record func(tx *sqlx.Tx, project *Project) error) (*Project, error)
+	PublishScheduled() (int64, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func (r repo) List(q *listquery.Query, publishedOnly bool, tagFilter TagFilter) (*ListProject, error) {
+	var whereArgs []interface{}
+	projects := ListProject{Projects: []Project{}}
+
+	where := notDeletedCondition
+	if publishedOnly {
+		where = publishedCondition
+	}
+
+	if len(tagFilter.Slugs) != 0 {
+		whereArgs = append(whereArgs, pq.Array(tagFilter.Slugs))
+		tagged := "SELECT pt.project_id FROM project_tags pt JOIN tags t ON t.id = pt.tag_id WHERE t.slug = ANY($1)"
+		if tagFilter.MatchAll {
+			whereArgs = append(whereArgs, len(tagFilter.Slugs))
+			tagged += " GROUP BY pt.project_id HAVING COUNT(DISTINCT t.id) = $2"
+		}
+		where += " AND id IN (" + tagged + ")"
+	}
+
+	query, args := q.SelectSQL(projectColumns, "projects", where, whereArgs...)
+	err := r.db.Select(&projects.Projects, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	projects.Page, err = q.Paginate(&projects.Projects)
+	if err != nil {
+		return nil, err
+	}
+
+	projects.Total, err = q.Total(r.db, "projects", where, whereArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	projects.Count = len(projects.Projects)
+
+	return &projects, nil
+}
+
+func (r repo) GetByID(ID int) (*Project, error) {
+	var project Project
+	err := r.db.Get(&project, "SELECT "+projectColumns+" FROM projects WHERE id=$1 AND deleted_at IS NULL", ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrProjectNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &project, nil
+}
+
+func (r repo) GetBySlug(slug string) (*Project, error) {
+	var project Project
+	err := r.db.Get(&project, "SELECT "+projectColumns+" FROM projects WHERE slug=$1 AND deleted_at IS NULL", slug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrProjectNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &project, nil
+}
+
+// GetSlugRedirect returns the current slug of the project that used to be reachable through oldSlug
+func (r repo) GetSlugRedirect(oldSlug string) (string, error) {
+	slug, err := slugs.Projects.Redirect(r.db, oldSlug)
+	if errors.Cause(err) == slugs.ErrSlugNotFound {
+		return "", errors.Wrap(ErrProjectNotFound, err.Error())
+	}
+
+	return slug, err
+}
+
+// SlugExists checks whether slug is used, or kept as a redirect, by any project other than excludeID
+func (r repo) SlugExists(slug string, excludeID int) (bool, error) {
+	return slugs.Projects.Exists(r.db, slug, excludeID)
+}
+
+// Create puts the new project at the end of the manual ordering. The new project is passed
+// to record, which writes in the same transaction, before anything is committed.
+func (r repo) Create(name, clientName string, description *string, featured bool, slug string, record func(tx *sqlx.Tx, project *Project) error) (*Project, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	var project Project
+	err = tx.Get(&project, `
+		INSERT INTO projects (name, client_name, description, featured, slug, position)
+		VALUES ($1, $2, $3, $4, $5, (SELECT COALESCE(MAX(position), 0) + 1 FROM projects))
+		RETURNING `+projectColumns, name, clientName, description, featured, slug)
+	if err != nil {
+		if slugs.Projects.IsTaken(err) {
+			return nil, errors.Wrap(slugs.ErrSlugTaken, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = record(tx, &project)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &project, nil
+}
+
+// Update saves the project and, when the slug changes, keeps the old one in slug_history as a redirect
+func (r repo) Update(ID int, name, clientName string, description *string, featured bool, slug string, record func(tx *sqlx.Tx, project *Project) error) (*Project, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	var oldSlug string
+	err = tx.Get(&oldSlug, "SELECT slug FROM projects WHERE id=$1 AND deleted_at IS NULL FOR UPDATE", ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrProjectNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	var project Project
+	err = tx.Get(&project, `
+		UPDATE projects SET name=$1, client_name=$2, description=$3, featured=$4, slug=$5 WHERE id=$6
+		RETURNING `+projectColumns, name, clientName, description, featured, slug, ID)
+	if err != nil {
+		if slugs.Projects.IsTaken(err) {
+			return nil, errors.Wrap(slugs.ErrSlugTaken, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = slugs.Projects.Move(tx, ID, oldSlug, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	err = record(tx, &project)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &project, nil
+}
+
+func (r repo) ListTeam(projectID int) ([]TeamMember, error) {
+	team := []TeamMember{}
+	err := r.db.Select(&team, `
+		SELECT pm.jastip_member_id, m.name, m.slug, m.photo, pm.role
+		FROM project_members pm
+		JOIN jastip_members m ON m.id = pm.jastip_member_id
+		WHERE pm.project_id=$1 AND m.deleted_at IS NULL
+		ORDER BY pm.id`, projectID)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return team, nil
+}
+
+// AssignMember credits a member on a project, updating the role if the member is already assigned
+func (r repo) AssignMember(projectID, memberID int, role string) (*TeamMember, error) {
+	var teamMember TeamMember
+	err := r.db.Get(&teamMember, `
+		WITH assigned AS (
+			INSERT INTO project_members (project_id, jastip_member_id, role)
+			SELECT $1, id, $3 FROM jastip_members WHERE id=$2 AND deleted_at IS NULL
+			ON CONFLICT (project_id, jastip_member_id) DO UPDATE SET role = EXCLUDED.role
+			RETURNING jastip_member_id, role
+		)
+		SELECT a.jastip_member_id, m.name, m.slug, m.photo, a.role
+		FROM assigned a
+		JOIN jastip_members m ON m.id = a.jastip_member_id`, projectID, memberID, role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrMemberNotFound, err.Error())
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == foreignKeyViolation {
+			return nil, errors.Wrap(ErrMemberNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &teamMember, nil
+}
+
+func (r repo) UnassignMember(projectID, memberID int) error {
+	res, err := r.db.Exec("DELETE FROM project_members WHERE project_id=$1 AND jastip_member_id=$2", projectID, memberID)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	if affected == 0 {
+		return errors.Wrap(ErrMemberNotAssigned, "no project_members row deleted")
+	}
+
+	return nil
+}
+
+// UpdateStatus moves a project through its lifecycle. publishAt schedules a draft, publishing
+// keeps an earlier publication date and archiving leaves publish_at untouched.
+func (r repo) UpdateStatus(ID int, status string, publishAt *time.Time, record func(tx *sqlx.Tx, project *Project) error) (*Project, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	var project Project
+	err = tx.Get(&project, `
+		UPDATE projects
+		SET status=$1, publish_at=CASE $1
+			WHEN 'draft' THEN $2::timestamptz
+			WHEN 'published' THEN LEAST(publish_at, NOW())
+			ELSE publish_at
+		END
+		WHERE id=$3 AND deleted_at IS NULL
+		RETURNING `+projectColumns, status, publishAt, ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrProjectNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = record(tx, &project)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &project, nil
+}
+
+// PublishScheduled publishes every draft whose publish_at has passed
+func (r repo) PublishScheduled() (int64, error) {
+	res, err := r.db.Exec("UPDATE projects SET status='published' WHERE status='draft' AND publish_at <= NOW() AND deleted_at IS NULL")
+	if err != nil {
+		return 0, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	published, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return published, nil
+}
+
+// Reorder moves IDs, in the given order, to the top of the manual ordering.
+// Projects not listed keep their relative order after them.
+func (r repo) Reorder(IDs []int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	var found int
+	err = tx.Get(&found, "SELECT COUNT(*) FROM projects WHERE id = ANY($1) AND deleted_at IS NULL", pq.Array(IDs))
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	if found != len(IDs) {
+		return errors.Wrap(ErrProjectNotFound, "some ids don't belong to a project")
+	}
+
+	_, err = tx.Exec(`
+		WITH ordered AS (
+			SELECT p.id, ROW_NUMBER() OVER (ORDER BY o.ord NULLS LAST, p.position, p.id) AS position
+			FROM projects p
+			LEFT JOIN unnest($1::int[]) WITH ORDINALITY AS o(id, ord) ON o.id = p.id
+			WHERE p.deleted_at IS NULL
+		)
+		UPDATE projects p SET position = ordered.position
+		FROM ordered
+		WHERE p.id = ordered.id`, pq.Array(IDs))
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+func (r repo) ListTags(projectID int) ([]Tag, error) {
+	tags := []Tag{}
+	err := r.db.Select(&tags, `
+		SELECT t.id, t.name, t.slug
+		FROM project_tags pt
+		JOIN tags t ON t.id = pt.tag_id
+		WHERE pt.project_id=$1
+		ORDER BY t.name`, projectID)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return tags, nil
+}
+
+// ListTestimonials lists approved testimonials of a project, pending and rejected ones never leave moderation
+func (r repo) ListTestimonials(projectID int) ([]Testimonial, error) {
+	testimonials := []Testimonial{}
+	err := r.db.Select(&testimonials, `
+		SELECT id, quote, author_name, author_title, rating
+		FROM testimonials
+		WHERE project_id=$1 AND status='approved'
+		ORDER BY rating DESC, created_at DESC`, projectID)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return testimonials, nil
+}
+
+// ListLinks lists the external links of a project, leaving out broken ones when hideBroken is set
+func (r repo) ListLinks(projectID int, hideBroken bool) ([]Link, error) {
+	links := []Link{}
+	err := r.db.Select(&links, `
+		SELECT id, type, label, url
+		FROM project_links
+		WHERE project_id=$1 AND NOT ($2 AND health = 'broken')
+		ORDER BY id`, projectID, hideBroken)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return links, nil
+}
+
+// ListReactions reads the saved reaction counts of projects, keyed by project id
+func (r repo) ListReactions(projectIDs []int) (map[int]map[string]int64, error) {
+	reactions := map[int]map[string]int64{}
+	if len(projectIDs) == 0 {
+		return reactions, nil
+	}
+
+	var rows []struct {
+		ProjectID int    `db:"project_id"`
+		Kind      string `db:"kind"`
+		Count     int64  `db:"count"`
+	}
+	err := r.db.Select(&rows, "SELECT project_id, kind, count FROM project_reactions WHERE project_id = ANY($1)", pq.Array(projectIDs))
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	for _, row := range rows {
+		if reactions[row.ProjectID] == nil {
+			reactions[row.ProjectID] = map[string]int64{}
+		}
+		reactions[row.ProjectID][row.Kind] = row.Count
+	}
+
+	return reactions, nil
+}
+
+// AssignTag tags a project, assigning an already assigned tag is a no-op
+func (r repo) AssignTag(projectID, tagID int) error {
+	_, err := r.db.Exec("INSERT INTO project_tags (project_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", projectID, tagID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == foreignKeyViolation {
+			return errors.Wrap(ErrTagNotFound, err.Error())
+		}
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+func (r repo) UnassignTag(projectID, tagID int) error {
+	res, err := r.db.Exec("DELETE FROM project_tags WHERE project_id=$1 AND tag_id=$2", projectID, tagID)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	if affected == 0 {
+		return errors.Wrap(ErrTagNotAssigned, "no project_tags row deleted")
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package project
+
+import (
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// StartScheduler publishes scheduled projects every interval until the process exits
+func StartScheduler(service Service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		for range ticker.C {
+			published, err := service.PublishScheduled()
+			if err != nil {
+				logrus.Error("[error while publishing scheduled projects] ", err)
+				continue
+			}
+
+			if published != 0 {
+				logrus.Infof("Published %d scheduled project(s)", published)
+			}
+		}
+	}()
+}
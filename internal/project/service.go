@@ -0,0 +1,359 @@
+package project
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/markdown"
+	"github.com/rafimuhammad01/portofolio-api/internal/reaction"
+	"github.com/rafimuhammad01/portofolio-api/internal/revision"
+	"github.com/rafimuhammad01/portofolio-api/internal/slugs"
+	"github.com/rafimuhammad01/portofolio-api/internal/translation"
+	"time"
+)
+
+// NewService hideBrokenLinks leaves links the link checker found broken out of public project details
+func NewService(repo Repo, translationService translation.Service, revisionService revision.Service, markdownService markdown.Service, hideBrokenLinks bool) Service {
+	return &service{
+		repo:               repo,
+		translationService: translationService,
+		revisionService:    revisionService,
+		markdownService:    markdownService,
+		hideBrokenLinks:    hideBrokenLinks,
+	}
+}
+
+type Service interface {
+	List(q *listquery.Query, preview bool, tagFilter TagFilter, locale string) (*ListProject, error)
+	Get(ID int, preview bool, locale string) (*Detail, error)
+	GetBySlug(slug string, preview bool, locale string) (*Detail, error)
+	ResolveSlug(oldSlug string) (string, error)
+	Create(name, clientName string, description *string, featured bool, editorID int) (*Project, error)
+	Update(ID int, name, clientName string, description *string, featured bool, editorID int) (*Project, error)
+	Revert(ID, revisionID, editorID int) (*Project, error)
+	Reorder(IDs []int) error
+	UpdateStatus(ID int, status string, publishAt *time.Time, editorID int) (*Project, error)
+	PublishScheduled() (int64, error)
+	AssignMember(projectID, memberID int, role string) (*TeamMember, error)
+	UnassignMember(projectID, memberID int) error
+	AssignTag(projectID, tagID int) ([]Tag, error)
+	UnassignTag(projectID, tagID int) error
+}
+
+type service struct {
+	repo               Repo
+	translationService translation.Service
+	revisionService    revision.Service
+	markdownService    markdown.Service
+	hideBrokenLinks    bool
+}
+
+// List only returns published projects unless an authenticated editor asked for a preview
+func (s service) List(q *listquery.Query, preview bool, tagFilter TagFilter, locale string) (*ListProject, error) {
+	projects, err := s.repo.List(q, !preview, tagFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	projects.Locales, err = s.translate(projects.Projects, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.renderDescriptions(projects.Projects)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.countReactions(projects.Projects)
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (s service) Get(ID int, preview bool, locale string) (*Detail, error) {
+	project, err := s.repo.GetByID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.detail(project, preview, locale)
+}
+
+func (s service) GetBySlug(slug string, preview bool, locale string) (*Detail, error) {
+	project, err := s.repo.GetBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.detail(project, preview, locale)
+}
+
+// ResolveSlug finds the current slug of a project that was renamed away from oldSlug
+func (s service) ResolveSlug(oldSlug string) (string, error) {
+	return s.repo.GetSlugRedirect(oldSlug)
+}
+
+func (s service) Create(name, clientName string, description *string, featured bool, editorID int) (*Project, error) {
+	var project *Project
+	err := s.saveWithSlug(name, 0, func(slug string) (err error) {
+		project, err = s.repo.Create(name, clientName, description, featured, slug, func(tx *sqlx.Tx, created *Project) error {
+			return s.revisionService.Record(tx, revision.TypeProject, created.ID, editorID, nil, created)
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.renderDescription(project)
+}
+
+func (s service) Update(ID int, name, clientName string, description *string, featured bool, editorID int) (*Project, error) {
+	current, err := s.repo.GetByID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var project *Project
+	save := func(slug string) (err error) {
+		project, err = s.repo.Update(ID, name, clientName, description, featured, slug, s.record(current, editorID))
+		return err
+	}
+
+	// Only generate a new slug when the name actually changes
+	if current.Name != name {
+		err = s.saveWithSlug(name, ID, save)
+	} else {
+		err = save(current.Slug)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.renderDescription(project)
+}
+
+// Revert saves the content of the project as it was in an earlier revision, which is recorded as a new revision.
+// The lifecycle status is left alone, reverting never publishes or unpublishes a project.
+func (s service) Revert(ID, revisionID, editorID int) (*Project, error) {
+	rev, err := s.revisionService.Get(revision.TypeProject, ID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Project
+	err = rev.Decode(&snapshot)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return s.Update(ID, snapshot.Name, snapshot.ClientName, snapshot.Description, snapshot.Featured, editorID)
+}
+
+func (s service) Reorder(IDs []int) error {
+	return s.repo.Reorder(IDs)
+}
+
+func (s service) UpdateStatus(ID int, status string, publishAt *time.Time, editorID int) (*Project, error) {
+	current, err := s.repo.GetByID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := s.repo.UpdateStatus(ID, status, publishAt, s.record(current, editorID))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.renderDescription(project)
+}
+
+// PublishScheduled publishes drafts whose publish_at has passed, returning how many were published
+func (s service) PublishScheduled() (int64, error) {
+	return s.repo.PublishScheduled()
+}
+
+func (s service) AssignMember(projectID, memberID int, role string) (*TeamMember, error) {
+	// Make sure project exist
+	_, err := s.repo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamMember, err := s.repo.AssignMember(projectID, memberID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return teamMember, nil
+}
+
+func (s service) UnassignMember(projectID, memberID int) error {
+	return s.repo.UnassignMember(projectID, memberID)
+}
+
+// AssignTag tags the project and returns every tag it now has
+func (s service) AssignTag(projectID, tagID int) ([]Tag, error) {
+	// Make sure project exist
+	_, err := s.repo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.AssignTag(projectID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListTags(projectID)
+}
+
+func (s service) UnassignTag(projectID, tagID int) error {
+	return s.repo.UnassignTag(projectID, tagID)
+}
+
+// saveWithSlug saves the project through save under a unique slug for name, picking another one when a concurrent save takes it first
+func (s service) saveWithSlug(name string, projectID int, save func(slug string) error) error {
+	return slugs.Save(name, slugs.Projects.EntityType, func(slug string) (bool, error) {
+		return s.repo.SlugExists(slug, projectID)
+	}, save)
+}
+
+// record saves the revision of a change to current inside the transaction that makes the change
+func (s service) record(current *Project, editorID int) func(tx *sqlx.Tx, project *Project) error {
+	return func(tx *sqlx.Tx, project *Project) error {
+		return s.revisionService.Record(tx, revision.TypeProject, project.ID, editorID, current, project)
+	}
+}
+
+func (s service) detail(project *Project, preview bool, locale string) (*Detail, error) {
+	// Unpublished projects don't exist as far as the public is concerned
+	if project.Status != StatusPublished && !preview {
+		return nil, errors.Wrap(ErrProjectNotFound, "project is not published")
+	}
+
+	projects := []Project{*project}
+	locales, err := s.translate(projects, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.renderDescriptions(projects)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.countReactions(projects)
+	if err != nil {
+		return nil, err
+	}
+	project = &projects[0]
+
+	tags, err := s.repo.ListTags(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Editors previewing a project see every link, broken or not
+	links, err := s.repo.ListLinks(project.ID, s.hideBrokenLinks && !preview)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := s.repo.ListTeam(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	testimonials, err := s.repo.ListTestimonials(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Detail{
+		Project:      *project,
+		Tags:         tags,
+		Links:        links,
+		Team:         team,
+		Testimonials: testimonials,
+		Locales:      locales,
+	}, nil
+}
+
+// translate swaps translatable fields of projects for their translation in locale, untranslated fields are left as is.
+// It returns the locales the projects ended up in.
+func (s service) translate(projects []Project, locale string) ([]string, error) {
+	IDs := make([]int, len(projects))
+	for i, project := range projects {
+		IDs[i] = project.ID
+	}
+
+	translations, err := s.translationService.Lookup(translation.TypeProject, IDs, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range projects {
+		fields := translations[projects[i].ID]
+		fields.Apply("name", &projects[i].Name)
+		fields.ApplyOptional("description", &projects[i].Description)
+	}
+
+	return translation.ServedLocales(translations, IDs, locale), nil
+}
+
+// countReactions fills in the reactions saved for projects, every kind of reaction is there even when nobody left it
+func (s service) countReactions(projects []Project) error {
+	IDs := make([]int, len(projects))
+	for i, project := range projects {
+		IDs[i] = project.ID
+	}
+
+	reactions, err := s.repo.ListReactions(IDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range projects {
+		projects[i].Reactions = make(map[string]int64, len(reaction.Kinds))
+		for _, kind := range reaction.Kinds {
+			projects[i].Reactions[kind] = reactions[projects[i].ID][kind]
+		}
+	}
+
+	return nil
+}
+
+// renderDescription renders the Markdown description of a single project
+func (s service) renderDescription(project *Project) (*Project, error) {
+	projects := []Project{*project}
+	err := s.renderDescriptions(projects)
+	if err != nil {
+		return nil, err
+	}
+
+	return &projects[0], nil
+}
+
+// renderDescriptions fills in the HTML and excerpt rendered from the Markdown description of projects
+func (s service) renderDescriptions(projects []Project) error {
+	for i := range projects {
+		if projects[i].Description == nil {
+			continue
+		}
+
+		rendered, err := s.markdownService.Render(*projects[i].Description)
+		if err != nil {
+			return err
+		}
+
+		html, excerpt := rendered.HTML, rendered.Excerpt
+		projects[i].DescriptionHTML = &html
+		projects[i].DescriptionExcerpt = &excerpt
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+// Count counts a hit on key and returns how many hits it got in the current window.
+// The window starts with the first hit and doesn't slide. Creating the key with its expiry
+// and incrementing it happen in one transaction, so a key can't be left without an expiry.
+func Count(rdb *redis.Client, key string, window time.Duration, ctx context.Context) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SetNX(ctx, key, 0, window)
+		incr = pipe.Incr(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
@@ -0,0 +1,47 @@
+package reaction
+
+import "time"
+
+// Kinds are the reactions visitors can leave on a project
+var Kinds = []string{"like", "clap", "love", "insightful"}
+
+const (
+	keyPrefix = "reaction:"
+
+	// dirtyKey holds the projects with reactions that haven't been saved to PostgreSQL yet
+	dirtyKey = keyPrefix + "dirty"
+
+	cookieName   = "visitor"
+	cookieMaxAge = 365 * 24 * 60 * 60
+	cookiePath   = "/api/v1/projects"
+)
+
+// Config tunes how reactions are counted
+type Config struct {
+	// RateLimit is how many reactions an IP address may leave or take back per RateWindow
+	RateLimit  int64
+	RateWindow time.Duration
+	// SecureCookie sends the visitor cookie over HTTPS only, which also lets a site on another domain send it along
+	SecureCookie bool
+}
+
+// Counts are the reactions on a project by kind
+type Counts map[string]int64
+
+// Reactions on a project, and which of them the visitor asking left
+type Reactions struct {
+	ProjectID int      `json:"project_id"`
+	Counts    Counts   `json:"counts"`
+	Reacted   []string `json:"reacted"`
+}
+
+// Pending are reactions left or taken back since the last flush, by kind
+type Pending map[string]int64
+
+// ReactionAPIResponse API response for Reactions
+type ReactionAPIResponse struct {
+	Status  int        `json:"status"`
+	Message string     `json:"message"`
+	Data    *Reactions `json:"data,omitempty"`
+	Errors  []string   `json:"errors,omitempty"`
+}
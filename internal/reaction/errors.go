@@ -0,0 +1,10 @@
+package reaction
+
+import "github.com/pkg/errors"
+
+var (
+	ErrProjectNotFound = errors.New("project not found")
+	ErrInvalidKind     = errors.New("reaction is not supported")
+	ErrTooManyRequests = errors.New("too many reactions, please try again later")
+	ErrInternalServer  = errors.New("internal server error")
+)
@@ -0,0 +1,21 @@
+package reaction
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// StartFlusher saves the reactions counted in Redis to PostgreSQL every interval until the process exits
+func StartFlusher(service Service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		for range ticker.C {
+			_, err := service.Flush(context.Background())
+			if err != nil {
+				logrus.Error("[error while flushing reactions] ", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,101 @@
+package reaction
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// GetReactions returns the reactions on a project, by id or slug, and which of them the visitor left
+func (h *Handler) GetReactions(c *gin.Context) {
+	res, err := h.service.Get(c.Param("id"), h.visitor(c), c)
+	if err != nil {
+		h.respondError(c, err, "[error while using get reactions service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &ReactionAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+// React leaves the visitor's reaction of kind on a project
+func (h *Handler) React(c *gin.Context) {
+	res, err := h.service.React(c.Param("id"), c.Param("kind"), h.visitor(c), c.ClientIP(), c)
+	if err != nil {
+		h.respondError(c, err, "[error while using react service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &ReactionAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+// Unreact takes back the visitor's reaction of kind on a project
+func (h *Handler) Unreact(c *gin.Context) {
+	res, err := h.service.Unreact(c.Param("id"), c.Param("kind"), h.visitor(c), c.ClientIP(), c)
+	if err != nil {
+		h.respondError(c, err, "[error while using unreact service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &ReactionAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+// visitor identifies the visitor by its cookie, handing out a new one to visitors without one
+func (h *Handler) visitor(c *gin.Context) string {
+	cookie, _ := c.Cookie(cookieName)
+	visitor, fresh := h.service.Visitor(cookie)
+	if fresh != nil {
+		http.SetCookie(c.Writer, fresh)
+	}
+
+	return visitor
+}
+
+func (h *Handler) respondError(c *gin.Context, err error, logPrefix string) {
+	switch errors.Cause(err) {
+	case ErrProjectNotFound:
+		c.JSON(http.StatusNotFound, &ReactionAPIResponse{
+			Status:  http.StatusNotFound,
+			Message: "not found",
+			Errors:  []string{ErrProjectNotFound.Error()},
+		})
+	case ErrInvalidKind:
+		c.JSON(http.StatusBadRequest, &ReactionAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  []string{ErrInvalidKind.Error()},
+		})
+	case ErrTooManyRequests:
+		c.JSON(http.StatusTooManyRequests, &ReactionAPIResponse{
+			Status:  http.StatusTooManyRequests,
+			Message: "too many requests",
+			Errors:  []string{ErrTooManyRequests.Error()},
+		})
+	default:
+		logrus.Error(logPrefix, err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+	}
+}
@@ -0,0 +1,252 @@
+package reaction
+
+import (
+	"context"
+	"database/sql"
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/ratelimit"
+	"strconv"
+	"time"
+)
+
+// NewRepo Redis for counting reactions as they happen and PostgreSQL for keeping them
+func NewRepo(db *sqlx.DB, rdb *redis.Client) Repo {
+	return &repo{
+		db:  db,
+		rdb: rdb,
+	}
+}
+
+type Repo interface {
+	Resolve(idOrSlug string) (int, error)
+	CountRequest(ipAddress string, window time.Duration, ctx context.Context) (int64, error)
+	React(projectID int, kind, visitor string, ctx context.Context) error
+	Unreact(projectID int, kind, visitor string, ctx context.Context) error
+	Reacted(projectID int, visitor string, ctx context.Context) ([]string, error)
+	Counts(projectID int, ctx context.Context) (Counts, error)
+	TakePending(ctx context.Context) (map[int]Pending, error)
+	RestorePending(projectID int, pending Pending, ctx context.Context) error
+	Save(projectID int, pending Pending) error
+}
+
+type repo struct {
+	db  *sqlx.DB
+	rdb *redis.Client
+}
+
+// Resolve finds the id of a published project, by id or slug
+func (r repo) Resolve(idOrSlug string) (int, error) {
+	var ID int
+	err := r.db.Get(&ID, `
+		SELECT id FROM projects
+		WHERE (slug = $1 OR id::text = $1) AND deleted_at IS NULL AND status = 'published'`, idOrSlug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.Wrap(ErrProjectNotFound, err.Error())
+		}
+		return 0, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return ID, nil
+}
+
+// CountRequest counts a reaction request from ipAddress and returns how many were made in the current window
+func (r repo) CountRequest(ipAddress string, window time.Duration, ctx context.Context) (int64, error) {
+	count, err := ratelimit.Count(r.rdb, keyPrefix+"rate_limit:"+ipAddress, window, ctx)
+	if err != nil {
+		return 0, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return count, nil
+}
+
+// React adds the visitor's reaction to a project, reacting the same way twice changes nothing
+func (r repo) React(projectID int, kind, visitor string, ctx context.Context) error {
+	added, err := r.rdb.SAdd(ctx, visitorsKey(projectID, kind), visitor).Result()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+	if added == 0 {
+		return nil
+	}
+
+	return r.addPending(projectID, Pending{kind: 1}, ctx)
+}
+
+// Unreact takes back the visitor's reaction to a project, if it had left one
+func (r repo) Unreact(projectID int, kind, visitor string, ctx context.Context) error {
+	removed, err := r.rdb.SRem(ctx, visitorsKey(projectID, kind), visitor).Result()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+	if removed == 0 {
+		return nil
+	}
+
+	return r.addPending(projectID, Pending{kind: -1}, ctx)
+}
+
+// Reacted lists the kinds of reaction the visitor left on a project
+func (r repo) Reacted(projectID int, visitor string, ctx context.Context) ([]string, error) {
+	cmds := make([]*redis.BoolCmd, len(Kinds))
+	_, err := r.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, kind := range Kinds {
+			cmds[i] = pipe.SIsMember(ctx, visitorsKey(projectID, kind), visitor)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	reacted := []string{}
+	for i, kind := range Kinds {
+		if cmds[i].Val() {
+			reacted = append(reacted, kind)
+		}
+	}
+
+	return reacted, nil
+}
+
+// Counts adds the reactions waiting in Redis to those already saved in PostgreSQL
+func (r repo) Counts(projectID int, ctx context.Context) (Counts, error) {
+	var rows []struct {
+		Kind  string `db:"kind"`
+		Count int64  `db:"count"`
+	}
+	err := r.db.Select(&rows, "SELECT kind, count FROM project_reactions WHERE project_id=$1", projectID)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	pending, err := r.rdb.HGetAll(ctx, pendingKey(projectID)).Result()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	counts := Counts{}
+	for _, kind := range Kinds {
+		counts[kind] = 0
+	}
+	for _, row := range rows {
+		if _, ok := counts[row.Kind]; ok {
+			counts[row.Kind] = row.Count
+		}
+	}
+	for kind, value := range pending {
+		delta, err := strconv.ParseInt(value, 10, 64)
+		if _, ok := counts[kind]; !ok || err != nil {
+			continue
+		}
+		if counts[kind] += delta; counts[kind] < 0 {
+			counts[kind] = 0
+		}
+	}
+
+	return counts, nil
+}
+
+// TakePending removes the reactions waiting in Redis and returns them by project. A project
+// is taken off the dirty list before its reactions are read, so any reaction coming in
+// meanwhile puts it back on for the next flush.
+func (r repo) TakePending(ctx context.Context) (map[int]Pending, error) {
+	members, err := r.rdb.SMembers(ctx, dirtyKey).Result()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	taken := make(map[int]Pending, len(members))
+	for _, member := range members {
+		projectID, err := strconv.Atoi(member)
+		if err != nil {
+			continue
+		}
+
+		err = r.rdb.SRem(ctx, dirtyKey, member).Err()
+		if err != nil {
+			return taken, errors.Wrap(ErrInternalServer, err.Error())
+		}
+
+		var get *redis.StringStringMapCmd
+		_, err = r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			get = pipe.HGetAll(ctx, pendingKey(projectID))
+			pipe.Del(ctx, pendingKey(projectID))
+			return nil
+		})
+		if err != nil {
+			return taken, errors.Wrap(ErrInternalServer, err.Error())
+		}
+
+		pending := Pending{}
+		for kind, value := range get.Val() {
+			delta, err := strconv.ParseInt(value, 10, 64)
+			if err == nil && delta != 0 {
+				pending[kind] = delta
+			}
+		}
+		if len(pending) != 0 {
+			taken[projectID] = pending
+		}
+	}
+
+	return taken, nil
+}
+
+// RestorePending puts reactions that couldn't be saved back in Redis for the next flush
+func (r repo) RestorePending(projectID int, pending Pending, ctx context.Context) error {
+	return r.addPending(projectID, pending, ctx)
+}
+
+// Save adds reactions to the counts of a project. Reactions on a project that was
+// purged in the meantime are dropped.
+func (r repo) Save(projectID int, pending Pending) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	for kind, delta := range pending {
+		_, err = tx.Exec(`
+			INSERT INTO project_reactions (project_id, kind, count)
+			SELECT id, $2::varchar, GREATEST($3::integer, 0) FROM projects WHERE id=$1
+			ON CONFLICT (project_id, kind) DO UPDATE SET count = GREATEST(project_reactions.count + $3, 0)`,
+			projectID, kind, delta)
+		if err != nil {
+			return errors.Wrap(ErrInternalServer, err.Error())
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+func (r repo) addPending(projectID int, pending Pending, ctx context.Context) error {
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for kind, delta := range pending {
+			pipe.HIncrBy(ctx, pendingKey(projectID), kind, delta)
+		}
+		pipe.SAdd(ctx, dirtyKey, projectID)
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+func visitorsKey(projectID int, kind string) string {
+	return keyPrefix + "visitors:" + strconv.Itoa(projectID) + ":" + kind
+}
+
+func pendingKey(projectID int) string {
+	return keyPrefix + "pending:" + strconv.Itoa(projectID)
+}
@@ -0,0 +1,176 @@
+package reaction
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+)
+
+func NewService(repo Repo, secretKey string, config Config) Service {
+	return &service{
+		repo:      repo,
+		secretKey: secretKey,
+		config:    config,
+	}
+}
+
+type Service interface {
+	Visitor(cookie string) (string, *http.Cookie)
+	Get(idOrSlug, visitor string, ctx context.Context) (*Reactions, error)
+	React(idOrSlug, kind, visitor, ipAddress string, ctx context.Context) (*Reactions, error)
+	Unreact(idOrSlug, kind, visitor, ipAddress string, ctx context.Context) (*Reactions, error)
+	Flush(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo      Repo
+	secretKey string
+	config    Config
+}
+
+// Visitor reads the anonymous visitor id out of its signed cookie. Visitors without
+// a valid cookie get a new id, along with the cookie to hand them.
+func (s service) Visitor(cookie string) (string, *http.Cookie) {
+	parts := strings.SplitN(cookie, ".", 2)
+	if len(parts) == 2 && parts[0] != "" && hmac.Equal([]byte(parts[1]), []byte(s.sign(parts[0]))) {
+		return parts[0], nil
+	}
+
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	visitor := base64.RawURLEncoding.EncodeToString(id)
+
+	// Browsers only send a cookie along to another site when it's SameSite=None, which has to be Secure
+	sameSite := http.SameSiteLaxMode
+	if s.config.SecureCookie {
+		sameSite = http.SameSiteNoneMode
+	}
+
+	return visitor, &http.Cookie{
+		Name:     cookieName,
+		Value:    visitor + "." + s.sign(visitor),
+		Path:     cookiePath,
+		MaxAge:   cookieMaxAge,
+		Secure:   s.config.SecureCookie,
+		HttpOnly: true,
+		SameSite: sameSite,
+	}
+}
+
+func (s service) Get(idOrSlug, visitor string, ctx context.Context) (*Reactions, error) {
+	projectID, err := s.repo.Resolve(idOrSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reactions(projectID, visitor, ctx)
+}
+
+func (s service) React(idOrSlug, kind, visitor, ipAddress string, ctx context.Context) (*Reactions, error) {
+	projectID, err := s.check(idOrSlug, kind, ipAddress, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.React(projectID, kind, visitor, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reactions(projectID, visitor, ctx)
+}
+
+func (s service) Unreact(idOrSlug, kind, visitor, ipAddress string, ctx context.Context) (*Reactions, error) {
+	projectID, err := s.check(idOrSlug, kind, ipAddress, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.Unreact(projectID, kind, visitor, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reactions(projectID, visitor, ctx)
+}
+
+// Flush saves the reactions waiting in Redis to PostgreSQL and returns for how many projects.
+// Reactions of a project that fail to save go back to Redis to be tried again.
+func (s service) Flush(ctx context.Context) (int, error) {
+	taken, err := s.repo.TakePending(ctx)
+
+	saved := 0
+	for projectID, pending := range taken {
+		saveErr := s.repo.Save(projectID, pending)
+		if saveErr == nil {
+			saved++
+			continue
+		}
+
+		err = saveErr
+		restoreErr := s.repo.RestorePending(projectID, pending, ctx)
+		if restoreErr != nil {
+			logrus.Error("[error while restoring pending reactions] ", restoreErr)
+		}
+	}
+
+	return saved, err
+}
+
+// check makes sure kind is a reaction, the request is within the rate limit and the project can be reacted to
+func (s service) check(idOrSlug, kind, ipAddress string, ctx context.Context) (int, error) {
+	if !IsKind(kind) {
+		return 0, errors.Wrap(ErrInvalidKind, kind)
+	}
+
+	count, err := s.repo.CountRequest(ipAddress, s.config.RateWindow, ctx)
+	if err != nil {
+		return 0, err
+	}
+	if count > s.config.RateLimit {
+		return 0, errors.Wrap(ErrTooManyRequests, ipAddress)
+	}
+
+	return s.repo.Resolve(idOrSlug)
+}
+
+func (s service) reactions(projectID int, visitor string, ctx context.Context) (*Reactions, error) {
+	counts, err := s.repo.Counts(projectID, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reacted, err := s.repo.Reacted(projectID, visitor, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reactions{
+		ProjectID: projectID,
+		Counts:    counts,
+		Reacted:   reacted,
+	}, nil
+}
+
+func (s service) sign(value string) string {
+	mac := hmac.New(sha256.New, []byte(s.secretKey))
+	mac.Write([]byte("reaction-visitor:" + value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// IsKind tells whether kind is one of the reactions visitors can leave
+func IsKind(kind string) bool {
+	for _, k := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
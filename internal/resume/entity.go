@@ -0,0 +1,117 @@
+package resume
+
+// jsonResumeSchema is the version of https://jsonresume.org/schema the JSON résumé follows
+const jsonResumeSchema = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+// Config of the site résumés link back to. Routes are paths on the site where
+// {slug} and {id} are replaced by those of the member or project.
+type Config struct {
+	SiteName     string
+	SiteURL      string
+	APIURL       string
+	MemberRoute  string
+	ProjectRoute string
+}
+
+// levels name the proficiency of a skill, an unrated skill has no level
+var levels = map[int]string{
+	1: "Beginner",
+	2: "Elementary",
+	3: "Intermediate",
+	4: "Advanced",
+	5: "Expert",
+}
+
+// Profile is everything public about a member that goes on a résumé
+type Profile struct {
+	Member   Member
+	Roles    []Role
+	Skills   []Skill
+	Projects []Project
+	// Locales are what the translatable fields were served in
+	Locales []string
+}
+
+type Member struct {
+	ID    int     `db:"id"`
+	Name  string  `db:"name"`
+	Slug  string  `db:"slug"`
+	Photo *string `db:"photo"`
+}
+
+type Role struct {
+	ID          int     `db:"id"`
+	Name        string  `db:"name"`
+	Description *string `db:"description"`
+}
+
+type Skill struct {
+	ID                int     `db:"id"`
+	Skill             string  `db:"skill"`
+	Description       *string `db:"description"`
+	Proficiency       int     `db:"proficiency"`
+	YearsOfExperience int     `db:"years_of_experience"`
+}
+
+// Project is a published project the member is credited on, with the role the member had
+type Project struct {
+	ID          int      `db:"id"`
+	Name        string   `db:"name"`
+	Slug        string   `db:"slug"`
+	ClientName  string   `db:"client_name"`
+	Description *string  `db:"description"`
+	Role        string   `db:"role"`
+	Tags        []string `db:"-"`
+}
+
+// Resume follows the JSON Resume schema, leaving out sections the portfolio has no data for
+type Resume struct {
+	Schema   string          `json:"$schema"`
+	Basics   Basics          `json:"basics"`
+	Skills   []ResumeSkill   `json:"skills"`
+	Projects []ResumeProject `json:"projects"`
+	Meta     Meta            `json:"meta"`
+	Locales  []string        `json:"-"`
+}
+
+type Basics struct {
+	Name    string `json:"name"`
+	Label   string `json:"label,omitempty"`
+	Image   string `json:"image,omitempty"`
+	URL     string `json:"url"`
+	Summary string `json:"summary,omitempty"`
+}
+
+type ResumeSkill struct {
+	Name        string `json:"name"`
+	Level       string `json:"level,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type ResumeProject struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Entity      string   `json:"entity"`
+	Roles       []string `json:"roles"`
+	Keywords    []string `json:"keywords"`
+	URL         string   `json:"url"`
+}
+
+type Meta struct {
+	Canonical string `json:"canonical"`
+	Version   string `json:"version"`
+}
+
+// ResumeAPIResponse API response for Resume, only used when there's no résumé to serve
+type ResumeAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// Document is a rendered PDF résumé
+type Document struct {
+	Filename string
+	Body     []byte
+	Locales  []string
+}
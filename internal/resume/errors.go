@@ -0,0 +1,8 @@
+package resume
+
+import "github.com/pkg/errors"
+
+var (
+	ErrMemberNotFound = errors.New("member not found")
+	ErrInternalServer = errors.New("internal server error")
+)
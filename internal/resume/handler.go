@@ -0,0 +1,62 @@
+package resume
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// GetMemberResumeJSON serves a member's résumé as a JSON Resume document, by id or slug
+func (h *Handler) GetMemberResumeJSON(c *gin.Context) {
+	res, err := h.service.JSON(c.Param("id"), utils.ResolveLocale(c))
+	if err != nil {
+		if errors.Cause(err) == ErrMemberNotFound {
+			c.JSON(http.StatusNotFound, &ResumeAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrMemberNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using json resume service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	utils.SetContentLanguage(c, res.Locales)
+	c.JSON(http.StatusOK, res)
+}
+
+// GetMemberResumePDF serves a member's résumé as a PDF, by id or slug
+func (h *Handler) GetMemberResumePDF(c *gin.Context) {
+	res, err := h.service.PDF(c.Param("id"), utils.ResolveLocale(c), c)
+	if err != nil {
+		if errors.Cause(err) == ErrMemberNotFound {
+			c.JSON(http.StatusNotFound, &ResumeAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrMemberNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using pdf resume service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	utils.SetContentLanguage(c, res.Locales)
+	c.Header("Content-Disposition", `inline; filename="`+res.Filename+`"`)
+	c.Data(http.StatusOK, "application/pdf", res.Body)
+}
@@ -0,0 +1,194 @@
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/go-pdf/fpdf"
+	"github.com/pkg/errors"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"strings"
+)
+
+// Sizes are in millimetres on an A4 page, except font sizes which are in points
+const (
+	margin     = 20
+	photoSize  = 32
+	photoEdge  = 512
+	lineGap    = 1.5
+	sectionGap = 8
+	fontFamily = "Go"
+)
+
+type rgb struct{ r, g, b int }
+
+var (
+	foreground = rgb{0x0f, 0x17, 0x2a}
+	muted      = rgb{0x64, 0x74, 0x8b}
+	accent     = rgb{0x02, 0x84, 0xc7}
+	rule       = rgb{0xcb, 0xd5, 0xe1}
+)
+
+// layout writes the résumé as an A4 PDF. The Go fonts are embedded so the PDF
+// looks the same everywhere and can hold any text a translation comes in.
+func layout(w io.Writer, profile *Profile, photo image.Image, siteName, memberURL string) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(true, margin)
+	pdf.AddUTF8FontFromBytes(fontFamily, "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", gobold.TTF)
+	pdf.SetTitle(profile.Member.Name, true)
+	pdf.SetAuthor(profile.Member.Name, true)
+	pdf.SetCreator(siteName, true)
+	pdf.AliasNbPages("")
+
+	pdf.SetFooterFunc(footer(pdf, siteName))
+	pdf.AddPage()
+
+	pageWidth, _ := pdf.GetPageSize()
+	width := pageWidth - 2*margin
+
+	headerWidth := width
+	if photo != nil {
+		err := placePhoto(pdf, photo, pageWidth-margin-photoSize, margin)
+		if err != nil {
+			return err
+		}
+		headerWidth -= photoSize + 6
+	}
+
+	setFont(pdf, "B", 24, foreground)
+	pdf.MultiCell(headerWidth, 10, profile.Member.Name, "", "L", false)
+	if len(profile.Roles) != 0 {
+		setFont(pdf, "", 12, muted)
+		pdf.MultiCell(headerWidth, 6, label(profile.Roles), "", "L", false)
+	}
+	pdf.Ln(lineGap)
+	setFont(pdf, "", 10, accent)
+	pdf.CellFormat(headerWidth, 5, memberURL, "", 1, "L", false, 0, memberURL)
+	if photo != nil && pdf.GetY() < margin+photoSize {
+		pdf.SetY(margin + photoSize)
+	}
+
+	if len(profile.Skills) != 0 {
+		heading(pdf, "Skills", width)
+		for _, skill := range profile.Skills {
+			keepTogether(pdf, 12)
+			setFont(pdf, "B", 11, foreground)
+			pdf.MultiCell(width, 5.5, skill.Skill, "", "L", false)
+			if summary := experience(skill); summary != "" {
+				setFont(pdf, "", 9, accent)
+				pdf.MultiCell(width, 4.5, summary, "", "L", false)
+			}
+			if skill.Description != nil && *skill.Description != "" {
+				setFont(pdf, "", 10, muted)
+				pdf.MultiCell(width, 5, *skill.Description, "", "L", false)
+			}
+			pdf.Ln(lineGap)
+		}
+	}
+
+	if len(profile.Projects) != 0 {
+		heading(pdf, "Projects", width)
+		for _, project := range profile.Projects {
+			keepTogether(pdf, 20)
+			setFont(pdf, "B", 12, foreground)
+			pdf.MultiCell(width, 6, project.Name, "", "L", false)
+			setFont(pdf, "", 10, muted)
+			pdf.MultiCell(width, 5, credit(project), "", "L", false)
+			if project.Description != nil && *project.Description != "" {
+				pdf.Ln(lineGap)
+				setFont(pdf, "", 10, foreground)
+				pdf.MultiCell(width, 5, *project.Description, "", "L", false)
+			}
+			if len(project.Tags) != 0 {
+				pdf.Ln(lineGap)
+				setFont(pdf, "", 9, accent)
+				pdf.MultiCell(width, 4.5, strings.Join(project.Tags, " · "), "", "L", false)
+			}
+			pdf.Ln(lineGap * 2)
+		}
+	}
+
+	err := pdf.Output(w)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+// footer puts the site name and the page number at the bottom of every page
+func footer(pdf *fpdf.Fpdf, siteName string) func() {
+	return func() {
+		pdf.SetY(-margin + 6)
+		setFont(pdf, "", 8, muted)
+		pdf.CellFormat(0, 4, siteName, "", 0, "L", false, 0, "")
+		pdf.SetX(margin)
+		pdf.CellFormat(0, 4, fmt.Sprintf("%d / {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	}
+}
+
+// heading starts a section with its title over a rule
+func heading(pdf *fpdf.Fpdf, title string, width float64) {
+	pdf.Ln(sectionGap)
+	keepTogether(pdf, 24)
+	setFont(pdf, "B", 14, foreground)
+	pdf.CellFormat(width, 7, title, "", 1, "L", false, 0, "")
+	y := pdf.GetY() + 1
+	pdf.SetDrawColor(rule.r, rule.g, rule.b)
+	pdf.SetLineWidth(0.3)
+	pdf.Line(margin, y, margin+width, y)
+	pdf.Ln(4)
+}
+
+// keepTogether moves to a new page when less than height is left on this one,
+// so titles aren't left at the bottom of a page apart from what they're about
+func keepTogether(pdf *fpdf.Fpdf, height float64) {
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+height > pageHeight-margin {
+		pdf.AddPage()
+	}
+}
+
+func setFont(pdf *fpdf.Fpdf, style string, size float64, c rgb) {
+	pdf.SetFont(fontFamily, style, size)
+	pdf.SetTextColor(c.r, c.g, c.b)
+}
+
+// credit is the member's role on a project and who the project was for
+func credit(project Project) string {
+	if project.ClientName == "" {
+		return project.Role
+	}
+
+	return project.Role + " · " + project.ClientName
+}
+
+// placePhoto draws the photo cropped to a square from its center
+func placePhoto(pdf *fpdf.Fpdf, photo image.Image, x, y float64) error {
+	bounds := photo.Bounds()
+	edge := bounds.Dx()
+	if bounds.Dy() < edge {
+		edge = bounds.Dy()
+	}
+	origin := image.Pt(bounds.Min.X+(bounds.Dx()-edge)/2, bounds.Min.Y+(bounds.Dy()-edge)/2)
+	square := image.NewRGBA(image.Rect(0, 0, edge, edge))
+	draw.Draw(square, square.Bounds(), photo, origin, draw.Src)
+
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, square, &jpeg.Options{Quality: 85})
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	options := fpdf.ImageOptions{ImageType: "JPG"}
+	pdf.RegisterImageOptionsReader("photo", options, &buf)
+	pdf.ImageOptions("photo", x, y, photoSize, photoSize, false, options, 0, "")
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package resume
+
+import (
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+type Repo interface {
+	GetProfile(idOrSlug string) (*Profile, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+// GetProfile reads a member with its roles, skills and the published projects it's credited on, leaving out anything in the trash
+func (r repo) GetProfile(idOrSlug string) (*Profile, error) {
+	profile := Profile{
+		Roles:    []Role{},
+		Skills:   []Skill{},
+		Projects: []Project{},
+	}
+
+	err := r.db.Get(&profile.Member, `
+		SELECT id, name, slug, photo FROM jastip_members
+		WHERE (slug = $1 OR id::text = $1) AND deleted_at IS NULL`, idOrSlug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrMemberNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = r.db.Select(&profile.Roles, `
+		SELECT DISTINCT r.id, r.name, r.description FROM jastip_member_roles mr
+		JOIN roles r ON r.id = mr.role_id AND r.deleted_at IS NULL
+		WHERE mr.jastip_member_id = $1
+		ORDER BY r.id`, profile.Member.ID)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = r.db.Select(&profile.Skills, `
+		SELECT id, skill, description, proficiency, years_of_experience FROM skills
+		WHERE jastip_member_id = $1 AND deleted_at IS NULL
+		ORDER BY rank DESC, id`, profile.Member.ID)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = r.db.Select(&profile.Projects, `
+		SELECT p.id, p.name, p.slug, p.client_name, p.description, pm.role FROM project_members pm
+		JOIN projects p ON p.id = pm.project_id
+		WHERE pm.jastip_member_id = $1 AND p.deleted_at IS NULL AND p.status = 'published'
+		ORDER BY p.publish_at DESC NULLS LAST, p.id DESC`, profile.Member.ID)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	if len(profile.Projects) == 0 {
+		return &profile, nil
+	}
+
+	IDs := make([]int64, len(profile.Projects))
+	index := make(map[int]*Project, len(profile.Projects))
+	for i := range profile.Projects {
+		profile.Projects[i].Tags = []string{}
+		IDs[i] = int64(profile.Projects[i].ID)
+		index[profile.Projects[i].ID] = &profile.Projects[i]
+	}
+
+	var tags []struct {
+		ProjectID int    `db:"project_id"`
+		Name      string `db:"name"`
+	}
+	err = r.db.Select(&tags, `
+		SELECT pt.project_id, t.name FROM project_tags pt
+		JOIN tags t ON t.id = pt.tag_id
+		WHERE pt.project_id = ANY($1)
+		ORDER BY t.name`, pq.Array(IDs))
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	for _, tag := range tags {
+		index[tag.ProjectID].Tags = append(index[tag.ProjectID].Tags, tag.Name)
+	}
+
+	return &profile, nil
+}
@@ -0,0 +1,208 @@
+package resume
+
+import (
+	"bytes"
+	"context"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/media"
+	"github.com/rafimuhammad01/portofolio-api/internal/storage"
+	"github.com/rafimuhammad01/portofolio-api/internal/translation"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"image"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+func NewService(repo Repo, translationService translation.Service, mediaService media.Service, config Config) Service {
+	return &service{
+		repo:               repo,
+		translationService: translationService,
+		mediaService:       mediaService,
+		config:             config,
+	}
+}
+
+type Service interface {
+	JSON(idOrSlug, locale string) (*Resume, error)
+	PDF(idOrSlug, locale string, ctx context.Context) (*Document, error)
+}
+
+type service struct {
+	repo               Repo
+	translationService translation.Service
+	mediaService       media.Service
+	config             Config
+}
+
+func (s service) JSON(idOrSlug, locale string) (*Resume, error) {
+	profile, err := s.profile(idOrSlug, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	memberURL := s.url(s.config.MemberRoute, profile.Member.Slug, profile.Member.ID)
+	resume := Resume{
+		Schema: jsonResumeSchema,
+		Basics: Basics{
+			Name:  profile.Member.Name,
+			Label: label(profile.Roles),
+			URL:   memberURL,
+		},
+		Skills:   make([]ResumeSkill, len(profile.Skills)),
+		Projects: make([]ResumeProject, len(profile.Projects)),
+		Meta: Meta{
+			Canonical: s.config.APIURL + "/api/v1/members/" + url.PathEscape(profile.Member.Slug) + "/resume.json",
+			Version:   "v1.0.0",
+		},
+		Locales: profile.Locales,
+	}
+	if profile.Member.Photo != nil {
+		resume.Basics.Image = utils.AbsoluteURL(s.config.APIURL, *profile.Member.Photo)
+	}
+
+	for i, skill := range profile.Skills {
+		resume.Skills[i] = ResumeSkill{Name: skill.Skill, Level: levels[skill.Proficiency]}
+		if skill.Description != nil {
+			resume.Skills[i].Description = *skill.Description
+		}
+	}
+
+	for i, project := range profile.Projects {
+		resume.Projects[i] = ResumeProject{
+			Name:     project.Name,
+			Entity:   project.ClientName,
+			Roles:    []string{project.Role},
+			Keywords: project.Tags,
+			URL:      s.url(s.config.ProjectRoute, project.Slug, project.ID),
+		}
+		if project.Description != nil {
+			resume.Projects[i].Description = *project.Description
+		}
+	}
+
+	return &resume, nil
+}
+
+func (s service) PDF(idOrSlug, locale string, ctx context.Context) (*Document, error) {
+	profile, err := s.profile(idOrSlug, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = layout(&buf, profile, s.loadPhoto(profile.Member, ctx), s.config.SiteName, s.url(s.config.MemberRoute, profile.Member.Slug, profile.Member.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Document{
+		Filename: profile.Member.Slug + "-resume.pdf",
+		Body:     buf.Bytes(),
+		Locales:  profile.Locales,
+	}, nil
+}
+
+// profile reads a member's profile with everything translatable in locale
+func (s service) profile(idOrSlug, locale string) (*Profile, error) {
+	profile, err := s.repo.GetProfile(idOrSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	roleIDs := make([]int, len(profile.Roles))
+	for i, role := range profile.Roles {
+		roleIDs[i] = role.ID
+	}
+	roleTranslations, err := s.translationService.Lookup(translation.TypeRole, roleIDs, locale)
+	if err != nil {
+		return nil, err
+	}
+	for i := range profile.Roles {
+		fields := roleTranslations[profile.Roles[i].ID]
+		fields.Apply("name", &profile.Roles[i].Name)
+		fields.ApplyOptional("description", &profile.Roles[i].Description)
+	}
+	profile.Locales = append(profile.Locales, translation.ServedLocales(roleTranslations, roleIDs, locale)...)
+
+	skillIDs := make([]int, len(profile.Skills))
+	for i, skill := range profile.Skills {
+		skillIDs[i] = skill.ID
+	}
+	skillTranslations, err := s.translationService.Lookup(translation.TypeSkill, skillIDs, locale)
+	if err != nil {
+		return nil, err
+	}
+	for i := range profile.Skills {
+		fields := skillTranslations[profile.Skills[i].ID]
+		fields.Apply("skill", &profile.Skills[i].Skill)
+		fields.ApplyOptional("description", &profile.Skills[i].Description)
+	}
+	profile.Locales = append(profile.Locales, translation.ServedLocales(skillTranslations, skillIDs, locale)...)
+
+	projectIDs := make([]int, len(profile.Projects))
+	for i, project := range profile.Projects {
+		projectIDs[i] = project.ID
+	}
+	projectTranslations, err := s.translationService.Lookup(translation.TypeProject, projectIDs, locale)
+	if err != nil {
+		return nil, err
+	}
+	for i := range profile.Projects {
+		fields := projectTranslations[profile.Projects[i].ID]
+		fields.Apply("name", &profile.Projects[i].Name)
+		fields.ApplyOptional("description", &profile.Projects[i].Description)
+	}
+	profile.Locales = append(profile.Locales, translation.ServedLocales(projectTranslations, projectIDs, locale)...)
+
+	return profile, nil
+}
+
+// loadPhoto decodes the member's photo. A résumé is still worth rendering without it,
+// which is what happens to photos hosted elsewhere or that can't be decoded.
+func (s service) loadPhoto(member Member, ctx context.Context) image.Image {
+	if member.Photo == nil {
+		return nil
+	}
+
+	img, err := s.mediaService.Load(ctx, *member.Photo, photoEdge)
+	if err != nil {
+		if errors.Cause(err) != storage.ErrInvalidKey {
+			logrus.Warn("[error while loading resume photo] ", err)
+		}
+		return nil
+	}
+
+	return img
+}
+
+func (s service) url(route, slug string, ID int) string {
+	return s.config.SiteURL + strings.NewReplacer("{slug}", url.PathEscape(slug), "{id}", strconv.Itoa(ID)).Replace(route)
+}
+
+// experience sums up how good the member is at a skill and for how long, empty when the member didn't say
+func experience(skill Skill) string {
+	var parts []string
+	if level, ok := levels[skill.Proficiency]; ok {
+		parts = append(parts, level)
+	}
+	switch {
+	case skill.YearsOfExperience == 1:
+		parts = append(parts, "1 year")
+	case skill.YearsOfExperience > 1:
+		parts = append(parts, strconv.Itoa(skill.YearsOfExperience)+" years")
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// label is what the member does, going by its roles
+func label(roles []Role) string {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = role.Name
+	}
+
+	return strings.Join(names, ", ")
+}
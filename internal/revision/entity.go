@@ -0,0 +1,102 @@
+package revision
+
+import (
+	"encoding/json"
+	"github.com/jmoiron/sqlx/types"
+	"github.com/lib/pq"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"time"
+)
+
+// Entity types that keep a revision history
+const (
+	TypeMember  = "member"
+	TypeProject = "project"
+	TypeSkill   = "skill"
+	TypeRole    = "role"
+)
+
+var entityTypes = map[string]bool{
+	TypeMember:  true,
+	TypeProject: true,
+	TypeSkill:   true,
+	TypeRole:    true,
+}
+
+// ignoredFields are identifying, derived or counter fields, they never count as changed.
+// Counters and the manual ordering move without anyone editing the entity.
+var ignoredFields = map[string]bool{
+	"id":                  true,
+	"image":               true,
+	"position":            true,
+	"endorsement_count":   true,
+	"reactions":           true,
+	"description_html":    true,
+	"description_excerpt": true,
+}
+
+// Revision entity represent revisions table in database, a snapshot of an entity right after it was saved
+type Revision struct {
+	ID            int            `json:"id" db:"id"`
+	EntityType    string         `json:"entity_type" db:"entity_type"`
+	EntityID      int            `json:"entity_id" db:"entity_id"`
+	EditorID      *int           `json:"editor_id" db:"editor_id"`
+	Editor        *string        `json:"editor" db:"editor"`
+	ChangedFields pq.StringArray `json:"changed_fields" db:"changed_fields"`
+	Snapshot      types.JSONText `json:"snapshot" db:"snapshot"`
+	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
+}
+
+// Decode unmarshals the snapshot into the entity it was taken from
+func (r Revision) Decode(dst interface{}) error {
+	return json.Unmarshal(r.Snapshot, dst)
+}
+
+// Change is a field that differs between two revisions
+type Change struct {
+	Field string          `json:"field"`
+	From  json.RawMessage `json:"from"`
+	To    json.RawMessage `json:"to"`
+}
+
+// Diff lists what changed from one revision to another
+type Diff struct {
+	From    int      `json:"from"`
+	To      int      `json:"to"`
+	Changes []Change `json:"changes"`
+}
+
+type ListRevision struct {
+	Revisions []Revision `json:"revisions"`
+	Count     int        `json:"count"`
+	listquery.Page
+}
+
+// listConfig whitelists sort and filter parameters for List
+var listConfig = listquery.Config{
+	Fields: map[string]listquery.Field{
+		"id":         {Column: "id", Type: listquery.TypeInt, Sortable: true},
+		"editor_id":  {Column: "editor_id", Type: listquery.TypeInt, Filterable: true},
+		"created_at": {Column: "created_at", Type: listquery.TypeTime, Sortable: true},
+	},
+	IDField:      "id",
+	DefaultSort:  "-id",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// ListRevisionAPIResponse API response for List
+type ListRevisionAPIResponse struct {
+	Status  int           `json:"status"`
+	Message string        `json:"message"`
+	Data    *ListRevision `json:"data,omitempty"`
+	Errors  []string      `json:"errors,omitempty"`
+}
+
+// DiffRevisionAPIResponse API response for Diff
+type DiffRevisionAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Diff    `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
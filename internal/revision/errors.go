@@ -0,0 +1,8 @@
+package revision
+
+import "github.com/pkg/errors"
+
+var (
+	ErrRevisionNotFound = errors.New("revision not found")
+	ErrInternalServer   = errors.New("internal server error")
+)
@@ -0,0 +1,111 @@
+package revision
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) GetAllRevision(c *gin.Context) {
+	// Input Validation
+	entityType, entityID, errorList := bindEntity(c)
+	q, queryErrors := listquery.Parse(c.Request.URL.Query(), listConfig)
+	errorList = append(errorList, queryErrors...)
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ListRevisionAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.List(q, entityType, entityID)
+	if err != nil {
+		logrus.Error("[error while using list revision service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &ListRevisionAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) GetRevisionDiff(c *gin.Context) {
+	// Input Validation
+	entityType, entityID, errorList := bindEntity(c)
+
+	fromID, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		errorList = append(errorList, "from should be a revision id")
+	}
+
+	toID, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		errorList = append(errorList, "to should be a revision id")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &DiffRevisionAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Diff(entityType, entityID, fromID, toID)
+	if err != nil {
+		if errors.Cause(err) == ErrRevisionNotFound {
+			c.JSON(http.StatusNotFound, &DiffRevisionAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrRevisionNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using diff revision service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &DiffRevisionAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func bindEntity(c *gin.Context) (string, int, []string) {
+	var errorList []string
+
+	entityType := c.Param("type")
+	if !entityTypes[entityType] {
+		errorList = append(errorList, "type should be one of member, project, skill or role")
+	}
+
+	entityID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	return entityType, entityID, errorList
+}
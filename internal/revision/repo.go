@@ -0,0 +1,96 @@
+package revision
+
+import (
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+)
+
+const (
+	revisionColumns = "id, entity_type, entity_id, editor_id, editor, changed_fields, snapshot, created_at"
+
+	// revisionsFrom joins in the username of the editor
+	revisionsFrom = `(
+		SELECT r.*, u.username AS editor
+		FROM revisions r
+		LEFT JOIN users u ON u.id = r.editor_id
+	) revisions_view`
+)
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+type Repo interface {
+	Exists(tx *sqlx.Tx, entityType string, entityID int) (bool, error)
+	Create(tx *sqlx.Tx, entityType string, entityID int, editorID *int, changedFields []string, snapshot []byte) error
+	List(q *listquery.Query, entityType string, entityID int) (*ListRevision, error)
+	GetByID(entityType string, entityID, ID int) (*Revision, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func (r repo) Exists(tx *sqlx.Tx, entityType string, entityID int) (bool, error) {
+	var exists bool
+	err := tx.Get(&exists, "SELECT EXISTS (SELECT 1 FROM revisions WHERE entity_type=$1 AND entity_id=$2)", entityType, entityID)
+	if err != nil {
+		return false, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return exists, nil
+}
+
+func (r repo) Create(tx *sqlx.Tx, entityType string, entityID int, editorID *int, changedFields []string, snapshot []byte) error {
+	_, err := tx.Exec("INSERT INTO revisions (entity_type, entity_id, editor_id, changed_fields, snapshot) VALUES ($1, $2, $3, $4, $5)",
+		entityType, entityID, editorID, pq.Array(changedFields), string(snapshot))
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+func (r repo) List(q *listquery.Query, entityType string, entityID int) (*ListRevision, error) {
+	revisions := ListRevision{Revisions: []Revision{}}
+	where := "entity_type = $1 AND entity_id = $2"
+
+	query, args := q.SelectSQL(revisionColumns, revisionsFrom, where, entityType, entityID)
+	err := r.db.Select(&revisions.Revisions, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	revisions.Page, err = q.Paginate(&revisions.Revisions)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions.Total, err = q.Total(r.db, revisionsFrom, where, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions.Count = len(revisions.Revisions)
+
+	return &revisions, nil
+}
+
+func (r repo) GetByID(entityType string, entityID, ID int) (*Revision, error) {
+	var revision Revision
+	err := r.db.Get(&revision, "SELECT "+revisionColumns+" FROM "+revisionsFrom+" WHERE id=$1 AND entity_type=$2 AND entity_id=$3", ID, entityType, entityID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrRevisionNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &revision, nil
+}
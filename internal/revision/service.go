@@ -0,0 +1,192 @@
+package revision
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"sort"
+)
+
+func NewService(repo Repo) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+type Service interface {
+	Record(tx *sqlx.Tx, entityType string, entityID, editorID int, before, after interface{}) error
+	List(q *listquery.Query, entityType string, entityID int) (*ListRevision, error)
+	Get(entityType string, entityID, ID int) (*Revision, error)
+	Diff(entityType string, entityID, fromID, toID int) (*Diff, error)
+}
+
+type service struct {
+	repo Repo
+}
+
+// Record saves a revision of an entity after it was created (before is nil) or updated by editorID.
+// It runs in tx, the transaction that saved the entity, so the revision commits or rolls back with it.
+// Entities saved before revisions existed get their previous state saved first, so it can be reverted to.
+// Saves that didn't change anything aren't recorded.
+func (s service) Record(tx *sqlx.Tx, entityType string, entityID, editorID int, before, after interface{}) error {
+	afterSnapshot, err := snapshot(after)
+	if err != nil {
+		return err
+	}
+
+	var beforeSnapshot map[string]json.RawMessage
+	if before != nil {
+		beforeSnapshot, err = snapshot(before)
+		if err != nil {
+			return err
+		}
+
+		exists, err := s.repo.Exists(tx, entityType, entityID)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			err = s.create(tx, entityType, entityID, nil, []string{}, beforeSnapshot)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	changedFields := changes(beforeSnapshot, afterSnapshot)
+	if before != nil && len(changedFields) == 0 {
+		return nil
+	}
+
+	fields := make([]string, len(changedFields))
+	for i, change := range changedFields {
+		fields[i] = change.Field
+	}
+
+	return s.create(tx, entityType, entityID, &editorID, fields, afterSnapshot)
+}
+
+func (s service) List(q *listquery.Query, entityType string, entityID int) (*ListRevision, error) {
+	revisions, err := s.repo.List(q, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (s service) Get(entityType string, entityID, ID int) (*Revision, error) {
+	revision, err := s.repo.GetByID(entityType, entityID, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+func (s service) Diff(entityType string, entityID, fromID, toID int) (*Diff, error) {
+	from, err := s.repo.GetByID(entityType, entityID, fromID)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.repo.GetByID(entityType, entityID, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fromSnapshot, toSnapshot map[string]json.RawMessage
+	err = json.Unmarshal(from.Snapshot, &fromSnapshot)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = json.Unmarshal(to.Snapshot, &toSnapshot)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	dropIgnored(fromSnapshot)
+	dropIgnored(toSnapshot)
+
+	return &Diff{
+		From:    fromID,
+		To:      toID,
+		Changes: changes(fromSnapshot, toSnapshot),
+	}, nil
+}
+
+func (s service) create(tx *sqlx.Tx, entityType string, entityID int, editorID *int, changedFields []string, snapshot map[string]json.RawMessage) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return s.repo.Create(tx, entityType, entityID, editorID, changedFields, data)
+}
+
+// snapshot encodes an entity the way the API shows it, without its ignored fields
+func snapshot(entity interface{}) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	dropIgnored(fields)
+
+	return fields, nil
+}
+
+// dropIgnored removes the ignored fields, snapshots saved before a field was ignored still have it
+func dropIgnored(fields map[string]json.RawMessage) {
+	for field := range ignoredFields {
+		delete(fields, field)
+	}
+}
+
+// changes lists the fields that differ between two snapshots, sorted by field name
+func changes(from, to map[string]json.RawMessage) []Change {
+	fields := map[string]bool{}
+	for field := range from {
+		fields[field] = true
+	}
+	for field := range to {
+		fields[field] = true
+	}
+
+	result := []Change{}
+	for field := range fields {
+		fromValue, toValue := normalize(from[field]), normalize(to[field])
+		if !bytes.Equal(fromValue, toValue) {
+			result = append(result, Change{Field: field, From: fromValue, To: toValue})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Field < result[j].Field
+	})
+
+	return result
+}
+
+// normalize re-encodes a JSON value compactly so JSONB formatting doesn't count as a change
+func normalize(value json.RawMessage) json.RawMessage {
+	if value == nil {
+		return json.RawMessage("null")
+	}
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, value); err != nil {
+		return value
+	}
+
+	return buf.Bytes()
+}
@@ -0,0 +1,50 @@
+package role
+
+import "github.com/rafimuhammad01/portofolio-api/internal/listquery"
+
+// Role entity represent roles table in database
+type Role struct {
+	ID          int     `json:"id" db:"id"`
+	Name        string  `json:"name" db:"name"`
+	Description *string `json:"description" db:"description"`
+}
+
+type ListRole struct {
+	Roles []Role `json:"roles"`
+	Count int    `json:"count"`
+	listquery.Page
+	Locales []string `json:"-"`
+}
+
+// listConfig whitelists sort and filter parameters for List
+var listConfig = listquery.Config{
+	Fields: map[string]listquery.Field{
+		"id":   {Column: "id", Type: listquery.TypeInt, Sortable: true, Filterable: true},
+		"name": {Column: "name", Sortable: true, Filterable: true},
+	},
+	IDField:      "id",
+	DefaultSort:  "id",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// ListRoleAPIResponse API response for List
+type ListRoleAPIResponse struct {
+	Status  int       `json:"status"`
+	Message string    `json:"message"`
+	Data    *ListRole `json:"data,omitempty"`
+	Errors  []string  `json:"errors,omitempty"`
+}
+
+// UpdateRoleAPIRequest update role request body from client
+type UpdateRoleAPIRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+}
+
+type UpdateRoleAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Role    `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
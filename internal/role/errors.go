@@ -0,0 +1,8 @@
+package role
+
+import "github.com/pkg/errors"
+
+var (
+	ErrRoleNotFound   = errors.New("role not found")
+	ErrInternalServer = errors.New("internal server error")
+)
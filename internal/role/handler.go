@@ -0,0 +1,164 @@
+package role
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/revision"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) GetAllRole(c *gin.Context) {
+	locale := utils.ResolveLocale(c)
+
+	// Input Validation
+	q, errorList := listquery.Parse(c.Request.URL.Query(), listConfig)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ListRoleAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.List(q, locale)
+	if err != nil {
+		logrus.Error("[error while using list role service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	utils.SetContentLanguage(c, res.Locales)
+	c.JSON(http.StatusOK, &ListRoleAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) UpdateRole(c *gin.Context) {
+	var (
+		errorList   []string
+		requestBody UpdateRoleAPIRequest
+	)
+
+	payload, err := utils.GetPayloadFromContext(c)
+	if err != nil {
+		logrus.Error("[error while extracting context] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	// Input Validation
+	roleID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	err = c.ShouldBindJSON(&requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	requestBody.Name = strings.TrimSpace(requestBody.Name)
+	if requestBody.Name == "" {
+		errorList = append(errorList, "name is required")
+	}
+
+	if len(requestBody.Name) > 128 {
+		errorList = append(errorList, "name should be less than 128 characters")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &UpdateRoleAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Update(roleID, requestBody.Name, requestBody.Description, payload.UserID)
+	if err != nil {
+		h.respondError(c, err, "[error while using update role service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &UpdateRoleAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) RevertRole(c *gin.Context) {
+	var errorList []string
+
+	payload, err := utils.GetPayloadFromContext(c)
+	if err != nil {
+		logrus.Error("[error while extracting context] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	// Input Validation
+	roleID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	revisionID, err := strconv.Atoi(c.Param("revision_id"))
+	if err != nil {
+		errorList = append(errorList, "revision_id should be a number")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &UpdateRoleAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Revert(roleID, revisionID, payload.UserID)
+	if err != nil {
+		h.respondError(c, err, "[error while using revert role service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &UpdateRoleAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) respondError(c *gin.Context, err error, logPrefix string) {
+	switch errors.Cause(err) {
+	case ErrRoleNotFound, revision.ErrRevisionNotFound:
+		c.JSON(http.StatusNotFound, &UpdateRoleAPIResponse{
+			Status:  http.StatusNotFound,
+			Message: "not found",
+			Errors:  []string{errors.Cause(err).Error()},
+		})
+	default:
+		logrus.Error(logPrefix, err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+	}
+}
@@ -0,0 +1,92 @@
+package role
+
+import (
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+)
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+type Repo interface {
+	List(q *listquery.Query) (*ListRole, error)
+	GetByID(ID int) (*Role, error)
+	Update(ID int, name string, description *string, record func(tx *sqlx.Tx, role *Role) error) (*Role, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func (r repo) List(q *listquery.Query) (*ListRole, error) {
+	roles := ListRole{Roles: []Role{}}
+
+	query, args := q.SelectSQL("id, name, description", "roles", "deleted_at IS NULL")
+	err := r.db.Select(&roles.Roles, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	roles.Page, err = q.Paginate(&roles.Roles)
+	if err != nil {
+		return nil, err
+	}
+
+	roles.Total, err = q.Total(r.db, "roles", "deleted_at IS NULL")
+	if err != nil {
+		return nil, err
+	}
+
+	roles.Count = len(roles.Roles)
+
+	return &roles, nil
+}
+
+func (r repo) GetByID(ID int) (*Role, error) {
+	var role Role
+	err := r.db.Get(&role, "SELECT id, name, description FROM roles WHERE id=$1 AND deleted_at IS NULL", ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrRoleNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &role, nil
+}
+
+// Update saves the role, record writes its revision in the same transaction before it commits
+func (r repo) Update(ID int, name string, description *string, record func(tx *sqlx.Tx, role *Role) error) (*Role, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	var role Role
+	err = tx.Get(&role, "UPDATE roles SET name=$1, description=$2 WHERE id=$3 AND deleted_at IS NULL RETURNING id, name, description", name, description, ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrRoleNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = record(tx, &role)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &role, nil
+}
@@ -0,0 +1,87 @@
+package role
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/revision"
+	"github.com/rafimuhammad01/portofolio-api/internal/translation"
+)
+
+func NewService(repo Repo, translationService translation.Service, revisionService revision.Service) Service {
+	return &service{
+		repo:               repo,
+		translationService: translationService,
+		revisionService:    revisionService,
+	}
+}
+
+type Service interface {
+	List(q *listquery.Query, locale string) (*ListRole, error)
+	Update(ID int, name string, description *string, editorID int) (*Role, error)
+	Revert(ID, revisionID, editorID int) (*Role, error)
+}
+
+type service struct {
+	repo               Repo
+	translationService translation.Service
+	revisionService    revision.Service
+}
+
+func (s service) List(q *listquery.Query, locale string) (*ListRole, error) {
+	roles, err := s.repo.List(q)
+	if err != nil {
+		return nil, err
+	}
+
+	IDs := make([]int, len(roles.Roles))
+	for i, role := range roles.Roles {
+		IDs[i] = role.ID
+	}
+
+	translations, err := s.translationService.Lookup(translation.TypeRole, IDs, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range roles.Roles {
+		fields := translations[roles.Roles[i].ID]
+		fields.Apply("name", &roles.Roles[i].Name)
+		fields.ApplyOptional("description", &roles.Roles[i].Description)
+	}
+	roles.Locales = translation.ServedLocales(translations, IDs, locale)
+
+	return roles, nil
+}
+
+func (s service) Update(ID int, name string, description *string, editorID int) (*Role, error) {
+	current, err := s.repo.GetByID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.repo.Update(ID, name, description, func(tx *sqlx.Tx, role *Role) error {
+		return s.revisionService.Record(tx, revision.TypeRole, ID, editorID, current, role)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// Revert saves the role as it was in an earlier revision, which is recorded as a new revision
+func (s service) Revert(ID, revisionID, editorID int) (*Role, error) {
+	rev, err := s.revisionService.Get(revision.TypeRole, ID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Role
+	err = rev.Decode(&snapshot)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return s.Update(ID, snapshot.Name, snapshot.Description, editorID)
+}
@@ -0,0 +1,32 @@
+package search
+
+// Result types returned by search
+const (
+	TypeProject = "project"
+	TypeMember  = "member"
+	TypeSkill   = "skill"
+)
+
+// Result is a single search hit from projects, jastip_members or skills table
+type Result struct {
+	Type      string  `json:"type" db:"type"`
+	ID        int     `json:"id" db:"id"`
+	Title     string  `json:"title" db:"title"`
+	Highlight string  `json:"highlight" db:"highlight"`
+	Rank      float64 `json:"rank" db:"rank"`
+}
+
+type ListResult struct {
+	Results []Result `json:"results"`
+	Page    int      `json:"page"`
+	Limit   int      `json:"limit"`
+	Total   int      `json:"total"`
+}
+
+// SearchAPIResponse API response for Search
+type SearchAPIResponse struct {
+	Status  int         `json:"status"`
+	Message string      `json:"message"`
+	Data    *ListResult `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
@@ -0,0 +1,72 @@
+package search
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultLimit = 10
+	maxLimit     = 50
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) Search(c *gin.Context) {
+	var errorList []string
+
+	// Input Validation
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		errorList = append(errorList, "q is required")
+	}
+
+	resultType := c.Query("type")
+	if resultType != "" && resultType != TypeProject && resultType != TypeMember && resultType != TypeSkill {
+		errorList = append(errorList, "type should be one of project, member or skill")
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		errorList = append(errorList, "page should be a positive number")
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultLimit)))
+	if err != nil || limit < 1 || limit > maxLimit {
+		errorList = append(errorList, "limit should be a number between 1 and "+strconv.Itoa(maxLimit))
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &SearchAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Search(query, resultType, page, limit)
+	if err != nil {
+		logrus.Error("[error while using search service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &SearchAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
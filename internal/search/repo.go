@@ -0,0 +1,98 @@
+package search
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"html"
+	"strings"
+)
+
+// Matches are marked with private use characters, which never show up in a document since
+// they're stripped from it first, so the highlight can be escaped before they become <mark> tags
+const (
+	startMark = "\uE000"
+	stopMark  = "\uE001"
+)
+
+var markReplacer = strings.NewReplacer(startMark, "<mark>", stopMark, "</mark>")
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+type Repo interface {
+	Search(query, resultType string, limit, offset int) (*ListResult, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+// matchesQuery finds the matches from every searchable table, searchQuery and countQuery build on it
+const matchesQuery = `
+	WITH q AS (
+		SELECT websearch_to_tsquery('simple', $1) AS query
+	), matches AS (
+		SELECT 'project' AS type, p.id, p.name AS title, concat_ws(' ', p.name, p.client_name, p.description) AS document, ts_rank(p.search_vector, q.query) AS rank
+		FROM projects p, q
+		WHERE p.search_vector @@ q.query AND p.status = 'published' AND p.deleted_at IS NULL
+		UNION ALL
+		SELECT 'member' AS type, m.id, m.name AS title, m.name AS document, ts_rank(m.search_vector, q.query) AS rank
+		FROM jastip_members m, q
+		WHERE m.search_vector @@ q.query AND m.deleted_at IS NULL
+		UNION ALL
+		SELECT 'skill' AS type, s.id, s.skill AS title, concat_ws(' ', s.skill, s.description) AS document, ts_rank(s.search_vector, q.query) AS rank
+		FROM skills s, q
+		WHERE s.search_vector @@ q.query AND s.deleted_at IS NULL
+	)`
+
+// searchQuery ranks the matches, then only highlights the requested page
+// since ts_headline is expensive to run on every matching row
+const searchQuery = matchesQuery + `, page AS (
+		SELECT type, id, title, document, rank, COUNT(*) OVER() AS total
+		FROM matches
+		WHERE $2 = '' OR type = $2
+		ORDER BY rank DESC, type, id
+		LIMIT $3 OFFSET $4
+	)
+	SELECT page.type, page.id, page.title, page.rank, page.total,
+		ts_headline('simple', translate(page.document, chr(57344) || chr(57345), ''), q.query,
+			'StartSel=' || chr(57344) || ', StopSel=' || chr(57345) || ', MaxFragments=2, MaxWords=20, MinWords=5') AS highlight
+	FROM page, q
+	ORDER BY page.rank DESC, page.type, page.id`
+
+// countQuery counts the matches, for when the requested page is past the last of them
+const countQuery = matchesQuery + `
+	SELECT COUNT(*) FROM matches WHERE $2 = '' OR type = $2`
+
+func (r repo) Search(query, resultType string, limit, offset int) (*ListResult, error) {
+	var rows []struct {
+		Result
+		Total int `db:"total"`
+	}
+
+	err := r.db.Select(&rows, searchQuery, query, resultType, limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	results := ListResult{Results: make([]Result, 0, len(rows))}
+	for _, row := range rows {
+		row.Highlight = markReplacer.Replace(html.EscapeString(row.Highlight))
+		results.Results = append(results.Results, row.Result)
+		results.Total = row.Total
+	}
+
+	// A page past the last match has no rows to read the total from
+	if len(rows) == 0 && offset > 0 {
+		err = r.db.Get(&results.Total, countQuery, query, resultType)
+		if err != nil {
+			return nil, errors.Wrap(ErrInternalServer, err.Error())
+		}
+	}
+
+	return &results, nil
+}
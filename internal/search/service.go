@@ -0,0 +1,34 @@
+package search
+
+// maxPage keeps the offset of a search from overflowing, nobody pages this deep anyway
+const maxPage = 1000
+
+func NewService(repo Repo) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+type Service interface {
+	Search(query, resultType string, page, limit int) (*ListResult, error)
+}
+
+type service struct {
+	repo Repo
+}
+
+func (s service) Search(query, resultType string, page, limit int) (*ListResult, error) {
+	if page > maxPage {
+		page = maxPage
+	}
+
+	results, err := s.repo.Search(query, resultType, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results.Page = page
+	results.Limit = limit
+
+	return results, nil
+}
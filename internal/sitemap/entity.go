@@ -0,0 +1,62 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+const xmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// Config of the site the sitemap describes. Routes are paths on the site where
+// {slug} and {id} are replaced by those of the member or project.
+type Config struct {
+	SiteURL      string
+	StaticRoutes []string
+	MemberRoute  string
+	ProjectRoute string
+	// MaxURLs per sitemap, beyond it the sitemap is split up behind a sitemap index
+	MaxURLs int
+}
+
+// Page is a public member or project, which gets a URL on the site
+type Page struct {
+	ID      int        `db:"id"`
+	Slug    string     `db:"slug"`
+	LastMod *time.Time `db:"lastmod"`
+}
+
+// Document is a rendered sitemap, sitemap index or robots.txt
+type Document struct {
+	Body         []byte
+	LastModified time.Time
+}
+
+type urlSet struct {
+	XMLName xml.Name   `xml:"urlset"`
+	Xmlns   string     `xml:"xmlns,attr"`
+	URLs    []urlEntry `xml:"url"`
+}
+
+type urlEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+	lastMod time.Time
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name       `xml:"sitemapindex"`
+	Xmlns    string         `xml:"xmlns,attr"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+type sitemapEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// SitemapAPIResponse API response for Sitemap, only used when there's no sitemap to serve
+type SitemapAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Errors  []string `json:"errors,omitempty"`
+}
@@ -0,0 +1,8 @@
+package sitemap
+
+import "github.com/pkg/errors"
+
+var (
+	ErrSitemapNotFound = errors.New("sitemap not found")
+	ErrInternalServer  = errors.New("internal server error")
+)
@@ -0,0 +1,79 @@
+package sitemap
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) GetSitemap(c *gin.Context) {
+	res, err := h.service.Sitemap()
+	if err != nil {
+		logrus.Error("[error while using sitemap service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	serveDocument(c, "application/xml; charset=utf-8", res)
+}
+
+// GetSitemapPage serves /sitemaps/:page where page is a number followed by .xml
+func (h *Handler) GetSitemapPage(c *gin.Context) {
+	number, err := strconv.Atoi(strings.TrimSuffix(c.Param("page"), ".xml"))
+	if err != nil || !strings.HasSuffix(c.Param("page"), ".xml") {
+		c.JSON(http.StatusNotFound, &SitemapAPIResponse{
+			Status:  http.StatusNotFound,
+			Message: "not found",
+			Errors:  []string{ErrSitemapNotFound.Error()},
+		})
+		return
+	}
+
+	res, err := h.service.Page(number)
+	if err != nil {
+		if errors.Cause(err) == ErrSitemapNotFound {
+			c.JSON(http.StatusNotFound, &SitemapAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrSitemapNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using sitemap service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	serveDocument(c, "application/xml; charset=utf-8", res)
+}
+
+func (h *Handler) GetRobots(c *gin.Context) {
+	serveDocument(c, "text/plain; charset=utf-8", h.service.Robots())
+}
+
+// serveDocument answers conditional requests with 304 Not Modified, matching
+// If-None-Match against a hash of the document and If-Modified-Since against its last change
+func serveDocument(c *gin.Context, contentType string, doc *Document) {
+	sum := sha256.Sum256(doc.Body)
+	c.Header("Content-Type", contentType)
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.Header("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	http.ServeContent(c.Writer, c.Request, "", doc.LastModified, bytes.NewReader(doc.Body))
+}
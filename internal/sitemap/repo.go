@@ -0,0 +1,56 @@
+package sitemap
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+type Repo interface {
+	ListMembers() ([]Page, error)
+	ListProjects() ([]Page, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+// ListMembers lists members not in the trash, last modified when their latest revision was made
+func (r repo) ListMembers() ([]Page, error) {
+	pages := []Page{}
+	err := r.db.Select(&pages, `
+		SELECT m.id, m.slug, rev.created_at AS lastmod FROM jastip_members m
+		LEFT JOIN LATERAL (
+			SELECT MAX(created_at) AS created_at FROM revisions WHERE entity_type = 'member' AND entity_id = m.id
+		) rev ON TRUE
+		WHERE m.deleted_at IS NULL
+		ORDER BY m.id`)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return pages, nil
+}
+
+// ListProjects lists published projects, last modified when they were published or last edited after that
+func (r repo) ListProjects() ([]Page, error) {
+	pages := []Page{}
+	err := r.db.Select(&pages, `
+		SELECT p.id, p.slug, GREATEST(p.publish_at, rev.created_at) AS lastmod FROM projects p
+		LEFT JOIN LATERAL (
+			SELECT MAX(created_at) AS created_at FROM revisions WHERE entity_type = 'project' AND entity_id = p.id
+		) rev ON TRUE
+		WHERE p.deleted_at IS NULL AND p.status = 'published'
+		ORDER BY p.id`)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return pages, nil
+}
@@ -0,0 +1,151 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func NewService(repo Repo, config Config) Service {
+	return &service{
+		repo:   repo,
+		config: config,
+	}
+}
+
+type Service interface {
+	Sitemap() (*Document, error)
+	Page(number int) (*Document, error)
+	Robots() *Document
+}
+
+type service struct {
+	repo   Repo
+	config Config
+}
+
+// Sitemap is built from the data on every request, so it's never out of date.
+// When there are more URLs than fit in one sitemap it's a sitemap index instead.
+func (s service) Sitemap() (*Document, error) {
+	urls, err := s.urls()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(urls) <= s.config.MaxURLs {
+		return render(urlSet{Xmlns: xmlns, URLs: urls}, lastModified(urls))
+	}
+
+	index := sitemapIndex{Xmlns: xmlns}
+	var modified time.Time
+	for number := 1; (number-1)*s.config.MaxURLs < len(urls); number++ {
+		page := s.page(urls, number)
+		entry := sitemapEntry{Loc: s.config.SiteURL + "/sitemaps/" + strconv.Itoa(number) + ".xml"}
+		if pageModified := lastModified(page); !pageModified.IsZero() {
+			entry.LastMod = pageModified.UTC().Format(time.RFC3339)
+			if pageModified.After(modified) {
+				modified = pageModified
+			}
+		}
+		index.Sitemaps = append(index.Sitemaps, entry)
+	}
+
+	return render(index, modified)
+}
+
+// Page is one of the sitemaps listed in the sitemap index, numbered from 1
+func (s service) Page(number int) (*Document, error) {
+	urls, err := s.urls()
+	if err != nil {
+		return nil, err
+	}
+
+	if number < 1 || (number-1)*s.config.MaxURLs >= len(urls) || len(urls) <= s.config.MaxURLs {
+		return nil, errors.Wrapf(ErrSitemapNotFound, "page %d of %d urls", number, len(urls))
+	}
+
+	page := s.page(urls, number)
+
+	return render(urlSet{Xmlns: xmlns, URLs: page}, lastModified(page))
+}
+
+func (s service) Robots() *Document {
+	return &Document{
+		Body: []byte(fmt.Sprintf("User-agent: *\nDisallow:\n\nSitemap: %s/sitemap.xml\n", s.config.SiteURL)),
+	}
+}
+
+// urls lists the site's pages in a stable order, so a URL stays in the same sitemap until pages before it are removed
+func (s service) urls() ([]urlEntry, error) {
+	var urls []urlEntry
+	for _, route := range s.config.StaticRoutes {
+		urls = append(urls, urlEntry{Loc: s.config.SiteURL + route})
+	}
+
+	members, err := s.repo.ListMembers()
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		urls = append(urls, s.url(s.config.MemberRoute, member))
+	}
+
+	projects, err := s.repo.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		urls = append(urls, s.url(s.config.ProjectRoute, project))
+	}
+
+	return urls, nil
+}
+
+func (s service) url(route string, page Page) urlEntry {
+	path := strings.NewReplacer("{slug}", url.PathEscape(page.Slug), "{id}", strconv.Itoa(page.ID)).Replace(route)
+
+	entry := urlEntry{Loc: s.config.SiteURL + path}
+	if page.LastMod != nil {
+		entry.lastMod = *page.LastMod
+		entry.LastMod = page.LastMod.UTC().Format(time.RFC3339)
+	}
+
+	return entry
+}
+
+func (s service) page(urls []urlEntry, number int) []urlEntry {
+	start := (number - 1) * s.config.MaxURLs
+	end := start + s.config.MaxURLs
+	if end > len(urls) {
+		end = len(urls)
+	}
+
+	return urls[start:end]
+}
+
+func lastModified(urls []urlEntry) time.Time {
+	var modified time.Time
+	for _, u := range urls {
+		if u.lastMod.After(modified) {
+			modified = u.lastMod
+		}
+	}
+
+	return modified
+}
+
+func render(doc interface{}, modified time.Time) (*Document, error) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &Document{
+		Body:         append([]byte(xml.Header), body...),
+		LastModified: modified,
+	}, nil
+}
@@ -0,0 +1,74 @@
+package skill
+
+import "github.com/rafimuhammad01/portofolio-api/internal/listquery"
+
+// Proficiency runs from 1 for a beginner to 5 for an expert, 0 means the member hasn't rated the skill
+const (
+	MinProficiency = 0
+	MaxProficiency = 5
+
+	MaxYearsOfExperience = 80
+)
+
+// Skill entity represent skills table in database
+type Skill struct {
+	ID                int     `json:"id" db:"id"`
+	Skill             string  `json:"skill" db:"skill"`
+	Description       *string `json:"description" db:"description"`
+	MemberID          *int    `json:"member_id" db:"jastip_member_id"`
+	Proficiency       int     `json:"proficiency" db:"proficiency"`
+	YearsOfExperience int     `json:"years_of_experience" db:"years_of_experience"`
+	EndorsementCount  int     `json:"endorsement_count" db:"endorsement_count"`
+	// Rank orders skills by proficiency, then endorsements
+	Rank int `json:"-" db:"rank"`
+	// DescriptionHTML and DescriptionExcerpt are rendered from the Markdown in Description
+	DescriptionHTML    *string `json:"description_html,omitempty" db:"-"`
+	DescriptionExcerpt *string `json:"description_excerpt,omitempty" db:"-"`
+}
+
+type ListSkill struct {
+	Skills []Skill `json:"skills"`
+	Count  int     `json:"count"`
+	listquery.Page
+	Locales []string `json:"-"`
+}
+
+// listConfig whitelists sort and filter parameters for List
+var listConfig = listquery.Config{
+	Fields: map[string]listquery.Field{
+		"id":                  {Column: "id", Type: listquery.TypeInt, Sortable: true, Filterable: true},
+		"skill":               {Column: "skill", Sortable: true, Filterable: true},
+		"member_id":           {Column: "jastip_member_id", Type: listquery.TypeInt, Filterable: true},
+		"proficiency":         {Column: "proficiency", Type: listquery.TypeInt, Sortable: true, Filterable: true},
+		"years_of_experience": {Column: "years_of_experience", Type: listquery.TypeInt, Sortable: true, Filterable: true},
+		"endorsement_count":   {Column: "endorsement_count", Type: listquery.TypeInt, Sortable: true, Filterable: true},
+		"rank":                {Column: "rank", Type: listquery.TypeInt, Sortable: true},
+	},
+	IDField:      "id",
+	DefaultSort:  "-rank",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// ListSkillAPIResponse API response for List
+type ListSkillAPIResponse struct {
+	Status  int        `json:"status"`
+	Message string     `json:"message"`
+	Data    *ListSkill `json:"data,omitempty"`
+	Errors  []string   `json:"errors,omitempty"`
+}
+
+// UpdateSkillAPIRequest update skill request body from client
+type UpdateSkillAPIRequest struct {
+	Skill             string  `json:"skill"`
+	Description       *string `json:"description"`
+	Proficiency       int     `json:"proficiency"`
+	YearsOfExperience int     `json:"years_of_experience"`
+}
+
+type UpdateSkillAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Skill   `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
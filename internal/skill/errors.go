@@ -0,0 +1,8 @@
+package skill
+
+import "github.com/pkg/errors"
+
+var (
+	ErrSkillNotFound  = errors.New("skill not found")
+	ErrInternalServer = errors.New("internal server error")
+)
@@ -0,0 +1,215 @@
+package skill
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/revision"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) GetAllSkill(c *gin.Context) {
+	locale := utils.ResolveLocale(c)
+
+	// Input Validation
+	q, errorList := listquery.Parse(c.Request.URL.Query(), listConfig)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ListSkillAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.List(q, locale)
+	if err != nil {
+		logrus.Error("[error while using list skill service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	utils.SetContentLanguage(c, res.Locales)
+	c.JSON(http.StatusOK, &ListSkillAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) UpdateSkill(c *gin.Context) {
+	var (
+		errorList   []string
+		requestBody UpdateSkillAPIRequest
+	)
+
+	payload, err := utils.GetPayloadFromContext(c)
+	if err != nil {
+		logrus.Error("[error while extracting context] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	// Input Validation
+	skillID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	err = c.ShouldBindJSON(&requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	requestBody.Skill = strings.TrimSpace(requestBody.Skill)
+	if requestBody.Skill == "" {
+		errorList = append(errorList, "skill is required")
+	}
+
+	if len(requestBody.Skill) > 128 {
+		errorList = append(errorList, "skill should be less than 128 characters")
+	}
+
+	if requestBody.Proficiency < MinProficiency || requestBody.Proficiency > MaxProficiency {
+		errorList = append(errorList, fmt.Sprintf("proficiency should be between %d and %d", MinProficiency, MaxProficiency))
+	}
+
+	if requestBody.YearsOfExperience < 0 || requestBody.YearsOfExperience > MaxYearsOfExperience {
+		errorList = append(errorList, fmt.Sprintf("years_of_experience should be between 0 and %d", MaxYearsOfExperience))
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &UpdateSkillAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Update(skillID, requestBody.Skill, requestBody.Description, requestBody.Proficiency, requestBody.YearsOfExperience, payload.UserID)
+	if err != nil {
+		h.respondError(c, err, "[error while using update skill service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &UpdateSkillAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) RevertSkill(c *gin.Context) {
+	var errorList []string
+
+	payload, err := utils.GetPayloadFromContext(c)
+	if err != nil {
+		logrus.Error("[error while extracting context] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	// Input Validation
+	skillID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	revisionID, err := strconv.Atoi(c.Param("revision_id"))
+	if err != nil {
+		errorList = append(errorList, "revision_id should be a number")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &UpdateSkillAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Revert(skillID, revisionID, payload.UserID)
+	if err != nil {
+		h.respondError(c, err, "[error while using revert skill service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &UpdateSkillAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+// EndorseSkill vouches for a skill on behalf of the signed in user
+func (h *Handler) EndorseSkill(c *gin.Context) {
+	h.endorsement(c, h.service.Endorse, "[error while using endorse skill service] ")
+}
+
+// UnendorseSkill takes back the signed in user's endorsement of a skill
+func (h *Handler) UnendorseSkill(c *gin.Context) {
+	h.endorsement(c, h.service.Unendorse, "[error while using unendorse skill service] ")
+}
+
+func (h *Handler) endorsement(c *gin.Context, endorse func(ID, userID int) (*Skill, error), logPrefix string) {
+	payload, err := utils.GetPayloadFromContext(c)
+	if err != nil {
+		logrus.Error("[error while extracting context] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	// Input Validation
+	skillID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &UpdateSkillAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  []string{"id should be a number"},
+		})
+		return
+	}
+
+	res, err := endorse(skillID, payload.UserID)
+	if err != nil {
+		h.respondError(c, err, logPrefix)
+		return
+	}
+
+	c.JSON(http.StatusOK, &UpdateSkillAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) respondError(c *gin.Context, err error, logPrefix string) {
+	switch errors.Cause(err) {
+	case ErrSkillNotFound, revision.ErrRevisionNotFound:
+		c.JSON(http.StatusNotFound, &UpdateSkillAPIResponse{
+			Status:  http.StatusNotFound,
+			Message: "not found",
+			Errors:  []string{errors.Cause(err).Error()},
+		})
+	default:
+		logrus.Error(logPrefix, err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+	}
+}
@@ -0,0 +1,147 @@
+package skill
+
+import (
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+)
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+type Repo interface {
+	List(q *listquery.Query) (*ListSkill, error)
+	GetByID(ID int) (*Skill, error)
+	Update(ID int, name string, description *string, proficiency, yearsOfExperience int, record func(tx *sqlx.Tx, skill *Skill) error) (*Skill, error)
+	Endorse(ID, userID int) (*Skill, error)
+	Unendorse(ID, userID int) (*Skill, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func (r repo) List(q *listquery.Query) (*ListSkill, error) {
+	skills := ListSkill{Skills: []Skill{}}
+
+	query, args := q.SelectSQL("id, skill, description, jastip_member_id, proficiency, years_of_experience, endorsement_count, rank", "skills", "deleted_at IS NULL")
+	err := r.db.Select(&skills.Skills, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	skills.Page, err = q.Paginate(&skills.Skills)
+	if err != nil {
+		return nil, err
+	}
+
+	skills.Total, err = q.Total(r.db, "skills", "deleted_at IS NULL")
+	if err != nil {
+		return nil, err
+	}
+
+	skills.Count = len(skills.Skills)
+
+	return &skills, nil
+}
+
+func (r repo) GetByID(ID int) (*Skill, error) {
+	var skill Skill
+	err := r.db.Get(&skill, "SELECT id, skill, description, jastip_member_id, proficiency, years_of_experience, endorsement_count, rank FROM skills WHERE id=$1 AND deleted_at IS NULL", ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrSkillNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &skill, nil
+}
+
+// Update saves the skill, record writes its revision in the same transaction before it commits
+func (r repo) Update(ID int, name string, description *string, proficiency, yearsOfExperience int, record func(tx *sqlx.Tx, skill *Skill) error) (*Skill, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	var skill Skill
+	err = tx.Get(&skill, `
+		UPDATE skills SET skill=$1, description=$2, proficiency=$3, years_of_experience=$4 WHERE id=$5 AND deleted_at IS NULL
+		RETURNING id, skill, description, jastip_member_id, proficiency, years_of_experience, endorsement_count, rank`, name, description, proficiency, yearsOfExperience, ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrSkillNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = record(tx, &skill)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &skill, nil
+}
+
+// Endorse records userID vouching for the skill, a user endorsing the same skill again changes nothing
+func (r repo) Endorse(ID, userID int) (*Skill, error) {
+	return r.endorsement(ID, `
+		INSERT INTO skill_endorsements (skill_id, user_id) VALUES ($1, $2)
+		ON CONFLICT (skill_id, user_id) DO NOTHING`, userID)
+}
+
+// Unendorse takes back userID's endorsement of the skill, if it had one
+func (r repo) Unendorse(ID, userID int) (*Skill, error) {
+	return r.endorsement(ID, "DELETE FROM skill_endorsements WHERE skill_id=$1 AND user_id=$2", userID)
+}
+
+// endorsement runs query on the skill's endorsements and recounts them. The skill
+// row is locked so concurrent endorsements can't leave the count behind.
+func (r repo) endorsement(ID int, query string, userID int) (*Skill, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.Get(&exists, "SELECT TRUE FROM skills WHERE id=$1 AND deleted_at IS NULL FOR UPDATE", ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrSkillNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	_, err = tx.Exec(query, ID, userID)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	var skill Skill
+	err = tx.Get(&skill, `
+		UPDATE skills SET endorsement_count=(SELECT COUNT(*) FROM skill_endorsements WHERE skill_id=$1) WHERE id=$1
+		RETURNING id, skill, description, jastip_member_id, proficiency, years_of_experience, endorsement_count, rank`, ID)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &skill, nil
+}
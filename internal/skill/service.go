@@ -0,0 +1,146 @@
+package skill
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/markdown"
+	"github.com/rafimuhammad01/portofolio-api/internal/revision"
+	"github.com/rafimuhammad01/portofolio-api/internal/translation"
+)
+
+func NewService(repo Repo, translationService translation.Service, revisionService revision.Service, markdownService markdown.Service) Service {
+	return &service{
+		repo:               repo,
+		translationService: translationService,
+		revisionService:    revisionService,
+		markdownService:    markdownService,
+	}
+}
+
+type Service interface {
+	List(q *listquery.Query, locale string) (*ListSkill, error)
+	Update(ID int, name string, description *string, proficiency, yearsOfExperience, editorID int) (*Skill, error)
+	Revert(ID, revisionID, editorID int) (*Skill, error)
+	Endorse(ID, userID int) (*Skill, error)
+	Unendorse(ID, userID int) (*Skill, error)
+}
+
+type service struct {
+	repo               Repo
+	translationService translation.Service
+	revisionService    revision.Service
+	markdownService    markdown.Service
+}
+
+func (s service) List(q *listquery.Query, locale string) (*ListSkill, error) {
+	skills, err := s.repo.List(q)
+	if err != nil {
+		return nil, err
+	}
+
+	IDs := make([]int, len(skills.Skills))
+	for i, skill := range skills.Skills {
+		IDs[i] = skill.ID
+	}
+
+	translations, err := s.translationService.Lookup(translation.TypeSkill, IDs, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range skills.Skills {
+		fields := translations[skills.Skills[i].ID]
+		fields.Apply("skill", &skills.Skills[i].Skill)
+		fields.ApplyOptional("description", &skills.Skills[i].Description)
+	}
+	skills.Locales = translation.ServedLocales(translations, IDs, locale)
+
+	err = s.renderDescriptions(skills.Skills)
+	if err != nil {
+		return nil, err
+	}
+
+	return skills, nil
+}
+
+func (s service) Update(ID int, name string, description *string, proficiency, yearsOfExperience, editorID int) (*Skill, error) {
+	current, err := s.repo.GetByID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	skill, err := s.repo.Update(ID, name, description, proficiency, yearsOfExperience, func(tx *sqlx.Tx, skill *Skill) error {
+		return s.revisionService.Record(tx, revision.TypeSkill, ID, editorID, current, skill)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.renderDescription(skill)
+}
+
+// Revert saves the skill as it was in an earlier revision, which is recorded as a new revision
+func (s service) Revert(ID, revisionID, editorID int) (*Skill, error) {
+	rev, err := s.revisionService.Get(revision.TypeSkill, ID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Skill
+	err = rev.Decode(&snapshot)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return s.Update(ID, snapshot.Skill, snapshot.Description, snapshot.Proficiency, snapshot.YearsOfExperience, editorID)
+}
+
+func (s service) Endorse(ID, userID int) (*Skill, error) {
+	skill, err := s.repo.Endorse(ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.renderDescription(skill)
+}
+
+func (s service) Unendorse(ID, userID int) (*Skill, error) {
+	skill, err := s.repo.Unendorse(ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.renderDescription(skill)
+}
+
+// renderDescription renders the Markdown description of a single skill
+func (s service) renderDescription(skill *Skill) (*Skill, error) {
+	skills := []Skill{*skill}
+	err := s.renderDescriptions(skills)
+	if err != nil {
+		return nil, err
+	}
+
+	return &skills[0], nil
+}
+
+// renderDescriptions fills in the HTML and excerpt rendered from the Markdown description of skills
+func (s service) renderDescriptions(skills []Skill) error {
+	for i := range skills {
+		if skills[i].Description == nil {
+			continue
+		}
+
+		rendered, err := s.markdownService.Render(*skills[i].Description)
+		if err != nil {
+			return err
+		}
+
+		html, excerpt := rendered.HTML, rendered.Excerpt
+		skills[i].DescriptionHTML = &html
+		skills[i].DescriptionExcerpt = &excerpt
+	}
+
+	return nil
+}
@@ -0,0 +1,9 @@
+package slugs
+
+import "github.com/pkg/errors"
+
+var (
+	ErrSlugTaken      = errors.New("slug is already taken")
+	ErrSlugNotFound   = errors.New("slug not found")
+	ErrInternalServer = errors.New("internal server error")
+)
@@ -0,0 +1,136 @@
+package slugs
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"strconv"
+)
+
+const (
+	// uniqueViolation is the PostgreSQL error code for unique_violation
+	uniqueViolation = "23505"
+
+	// maxAttempts is how many slugs Save tries when others keep taking them first
+	maxAttempts = 5
+)
+
+var (
+	Members  = Table{Name: "jastip_members", EntityType: "member"}
+	Projects = Table{Name: "projects", EntityType: "project"}
+)
+
+// Table is a table with a slug column. Old slugs of its rows are kept in slug_history under EntityType.
+type Table struct {
+	Name       string
+	EntityType string
+}
+
+// Exists checks whether slug is used, or kept as a redirect, by any row other than excludeID
+func (t Table) Exists(db sqlx.Queryer, slug string, excludeID int) (bool, error) {
+	var exists bool
+	err := sqlx.Get(db, &exists, `
+		SELECT EXISTS(SELECT 1 FROM `+t.Name+` WHERE slug=$1 AND id<>$2)
+			OR EXISTS(SELECT 1 FROM slug_history WHERE entity_type=$3 AND slug=$1 AND entity_id<>$2)`, slug, excludeID, t.EntityType)
+	if err != nil {
+		return false, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return exists, nil
+}
+
+// Redirect returns the current slug of the row that used to be reachable through oldSlug
+func (t Table) Redirect(db sqlx.Queryer, oldSlug string) (string, error) {
+	var slug string
+	err := sqlx.Get(db, &slug, `
+		SELECT t.slug
+		FROM slug_history h
+		JOIN `+t.Name+` t ON t.id = h.entity_id
+		WHERE h.entity_type=$1 AND h.slug=$2 AND t.deleted_at IS NULL`, t.EntityType, oldSlug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", errors.Wrap(ErrSlugNotFound, err.Error())
+		}
+		return "", errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return slug, nil
+}
+
+// Move keeps oldSlug of row ID in slug_history as a redirect once it's renamed to newSlug
+func (t Table) Move(tx sqlx.Execer, ID int, oldSlug, newSlug string) error {
+	if oldSlug == newSlug {
+		return nil
+	}
+
+	_, err := tx.Exec(`
+		INSERT INTO slug_history (entity_type, slug, entity_id) VALUES ($1, $2, $3)
+		ON CONFLICT (entity_type, slug) DO UPDATE SET entity_id = EXCLUDED.entity_id, created_at = NOW()`, t.EntityType, oldSlug, ID)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	// The row may be taking back one of its own old slugs
+	return t.Release(tx, newSlug)
+}
+
+// Release drops the redirect from an old slug which a row now holds
+func (t Table) Release(tx sqlx.Execer, slug string) error {
+	_, err := tx.Exec("DELETE FROM slug_history WHERE entity_type=$1 AND slug=$2", t.EntityType, slug)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+// IsTaken tells whether err is the unique constraint on the slug column of the table
+func (t Table) IsTaken(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code == uniqueViolation && pqErr.Constraint == t.Name+"_slug_key"
+}
+
+// Unique slugifies name, falling back to fallback when nothing is left,
+// and appends -2, -3, ... until exists reports the slug is free.
+// Purely numeric slugs are prefixed with fallback so they can't be mistaken for an id.
+func Unique(name, fallback string, exists func(slug string) (bool, error)) (string, error) {
+	base := utils.Slugify(name)
+	if base == "" {
+		base = fallback
+	} else if _, err := strconv.Atoi(base); err == nil {
+		base = fallback + "-" + base
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		taken, err := exists(slug)
+		if err != nil {
+			return "", err
+		}
+
+		if !taken {
+			return slug, nil
+		}
+
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// Save saves a row under a unique slug for name. Another row may take the slug between
+// picking and saving it, save then fails with ErrSlugTaken and another slug is picked.
+func Save(name, fallback string, exists func(slug string) (bool, error), save func(slug string) error) error {
+	for attempt := 1; ; attempt++ {
+		slug, err := Unique(name, fallback, exists)
+		if err != nil {
+			return err
+		}
+
+		err = save(slug)
+		if errors.Cause(err) != ErrSlugTaken || attempt == maxAttempts {
+			return err
+		}
+	}
+}
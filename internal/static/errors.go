@@ -0,0 +1,9 @@
+package static
+
+import "github.com/pkg/errors"
+
+var (
+	ErrUnexpectedResponse = errors.New("unexpected response from the api")
+	ErrInvalidPath        = errors.New("invalid path")
+	ErrInternalServer     = errors.New("internal server error")
+)
@@ -0,0 +1,377 @@
+package static
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// apiPrefix is where the API is mounted, pages are written under the same paths so the CDN serves them at the same URLs
+const apiPrefix = "/api/v1"
+
+// manifestName lists the files written by the last run, so files that are no longer part of the site can be removed
+const manifestName = ".static-manifest"
+
+// listing is a public list endpoint, detailed listings also get a page for each of their items by slug
+type listing struct {
+	path     string
+	key      string
+	detailed bool
+}
+
+var listings = []listing{
+	{path: "/members", key: "members", detailed: true},
+	{path: "/projects", key: "projects", detailed: true},
+	{path: "/skills", key: "skills"},
+	{path: "/roles", key: "roles"},
+	{path: "/tags", key: "tags"},
+	{path: "/photos", key: "photos"},
+	{path: "/testimonials", key: "testimonials"},
+}
+
+// Config of a static export. The first locale is the default one, written at the root of OutDir,
+// every other locale gets a directory of its own.
+type Config struct {
+	OutDir       string
+	HTML         bool
+	Locales      []string
+	MediaBaseURL string
+}
+
+// Result counts what a run did to the files in OutDir
+type Result struct {
+	Written   int
+	Unchanged int
+	Removed   int
+}
+
+// Exporter pre-renders the public portfolio by requesting every page from the API handler in process,
+// so the files are exactly what the API would have answered
+type Exporter struct {
+	handler http.Handler
+	config  Config
+	files   map[string]bool
+	media   map[string]bool
+	result  Result
+}
+
+func NewExporter(handler http.Handler, config Config) *Exporter {
+	return &Exporter{
+		handler: handler,
+		config:  config,
+	}
+}
+
+func (e *Exporter) Run() (*Result, error) {
+	e.files = make(map[string]bool)
+	e.media = make(map[string]bool)
+	e.result = Result{}
+
+	for i, locale := range e.config.Locales {
+		prefix := ""
+		if i != 0 {
+			prefix = "/" + locale
+		}
+
+		for _, l := range listings {
+			err := e.exportListing(l, prefix, locale)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err := e.exportMedia()
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.prune()
+	if err != nil {
+		return nil, err
+	}
+
+	return &e.result, nil
+}
+
+// exportListing writes every page of a listing, following its cursor, then the items on them
+func (e *Exporter) exportListing(l listing, prefix, locale string) error {
+	query := url.Values{"lang": {locale}}
+	var items []map[string]interface{}
+
+	for page := 1; ; page++ {
+		body, err := e.get(apiPrefix+l.path, query)
+		if err != nil {
+			return err
+		}
+
+		filename := prefix + apiPrefix + l.path
+		if page > 1 {
+			filename += "/page/" + strconv.Itoa(page)
+		}
+
+		err = e.write(filename+"/index.json", body)
+		if err != nil {
+			return err
+		}
+
+		var res struct {
+			Data map[string]json.RawMessage `json:"data"`
+		}
+		err = json.Unmarshal(body, &res)
+		if err != nil {
+			return errors.Wrap(ErrUnexpectedResponse, err.Error())
+		}
+
+		var pageItems []map[string]interface{}
+		err = json.Unmarshal(res.Data[l.key], &pageItems)
+		if err != nil {
+			return errors.Wrap(ErrUnexpectedResponse, err.Error())
+		}
+		items = append(items, pageItems...)
+
+		var cursor *string
+		err = json.Unmarshal(res.Data["next_cursor"], &cursor)
+		if err != nil || cursor == nil {
+			break
+		}
+		query.Set("cursor", *cursor)
+	}
+
+	if e.config.HTML && l.detailed {
+		err := e.renderHTML(listTemplate, prefix+"/"+l.key+"/index.html", pageData{
+			Prefix: prefix,
+			Locale: locale,
+			Key:    l.key,
+			Items:  items,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if !l.detailed {
+		return nil
+	}
+
+	for _, item := range items {
+		slug, _ := item["slug"].(string)
+		if slug == "" {
+			continue
+		}
+
+		body, err := e.get(apiPrefix+l.path+"/"+slug, url.Values{"lang": {locale}})
+		if err != nil {
+			return err
+		}
+
+		err = e.write(prefix+apiPrefix+l.path+"/"+slug+"/index.json", body)
+		if err != nil {
+			return err
+		}
+
+		if e.config.HTML {
+			var res struct {
+				Data map[string]interface{} `json:"data"`
+			}
+			err = json.Unmarshal(body, &res)
+			if err != nil {
+				return errors.Wrap(ErrUnexpectedResponse, err.Error())
+			}
+
+			err = e.renderHTML(detailTemplate, prefix+"/"+l.key+"/"+slug+"/index.html", pageData{
+				Prefix: prefix,
+				Locale: locale,
+				Key:    l.key,
+				Item:   res.Data,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// exportMedia copies every uploaded file the pages refer to. Media keys are content addressed,
+// so a file that is already there never needs rewriting.
+func (e *Exporter) exportMedia() error {
+	var sources []string
+	for source := range e.media {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	for _, source := range sources {
+		key := strings.TrimPrefix(source, strings.TrimSuffix(e.config.MediaBaseURL, "/")+"/")
+		body, err := e.get(apiPrefix+"/media/"+key, nil)
+		if err != nil {
+			return err
+		}
+
+		u, err := url.Parse(source)
+		if err != nil {
+			return errors.Wrap(ErrUnexpectedResponse, err.Error())
+		}
+
+		err = e.write(u.Path, body)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// get requests a page from the API, the way an anonymous visitor would
+func (e *Exporter) get(target string, query url.Values) ([]byte, error) {
+	if len(query) != 0 {
+		target += "?" + query.Encode()
+	}
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rec := httptest.NewRecorder()
+	e.handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		return nil, errors.Wrapf(ErrUnexpectedResponse, "GET %s answered %d", target, rec.Code)
+	}
+
+	body := rec.Body.Bytes()
+	if strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json") {
+		var data interface{}
+		err := json.Unmarshal(body, &data)
+		if err != nil {
+			return nil, errors.Wrap(ErrUnexpectedResponse, err.Error())
+		}
+		e.collectMedia(data)
+	}
+
+	return body, nil
+}
+
+// collectMedia finds URLs of uploaded files anywhere in a response
+func (e *Exporter) collectMedia(data interface{}) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for _, value := range v {
+			e.collectMedia(value)
+		}
+	case []interface{}:
+		for _, value := range v {
+			e.collectMedia(value)
+		}
+	case string:
+		if strings.HasPrefix(v, strings.TrimSuffix(e.config.MediaBaseURL, "/")+"/") {
+			e.media[v] = true
+		}
+	}
+}
+
+// write saves a file under OutDir unless it already has the same content
+func (e *Exporter) write(name string, content []byte) error {
+	name = path.Clean("/" + name)
+	if strings.Contains(name, "..") {
+		return errors.Wrap(ErrInvalidPath, name)
+	}
+	e.files[name] = true
+
+	filename := filepath.Join(e.config.OutDir, filepath.FromSlash(name))
+	current, err := ioutil.ReadFile(filename)
+	if err == nil && bytes.Equal(current, content) {
+		e.result.Unchanged++
+		return nil
+	}
+
+	err = writeFile(filename, content)
+	if err != nil {
+		return err
+	}
+	e.result.Written++
+
+	return nil
+}
+
+// prune removes files written by the last run that aren't part of the site anymore, then records this run
+func (e *Exporter) prune() error {
+	manifestFile := filepath.Join(e.config.OutDir, manifestName)
+
+	previous, err := ioutil.ReadFile(manifestFile)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	for _, name := range strings.Split(string(previous), "\n") {
+		if name == "" || e.files[name] || strings.Contains(name, "..") {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(e.config.OutDir, filepath.FromSlash(name)))
+		if err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(ErrInternalServer, err.Error())
+		}
+		e.result.Removed++
+	}
+
+	var names []string
+	for name := range e.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	manifest := []byte(strings.Join(names, "\n") + "\n")
+	if bytes.Equal(previous, manifest) {
+		return nil
+	}
+
+	return writeFile(manifestFile, manifest)
+}
+
+// writeFile replaces a file through a temporary one, so the CDN never picks up a half written file
+func writeFile(filename string, content []byte) error {
+	err := os.MkdirAll(filepath.Dir(filename), 0755)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(filename), ".static-*")
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(content)
+	if err != nil {
+		tmp.Close()
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = os.Chmod(tmp.Name(), 0644)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = os.Rename(tmp.Name(), filename)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, fmt.Sprintf("%s: %s", filename, err))
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package static
+
+import (
+	"bytes"
+	"github.com/pkg/errors"
+	"html/template"
+	"strings"
+)
+
+// pageData is what the HTML templates render, items are the decoded API JSON
+type pageData struct {
+	Prefix string
+	Locale string
+	Key    string
+	Items  []map[string]interface{}
+	Item   map[string]interface{}
+}
+
+var funcs = template.FuncMap{
+	"title": func(s string) string {
+		return strings.ToUpper(s[:1]) + s[1:]
+	},
+}
+
+const layout = `<!DOCTYPE html>
+<html lang="{{.Locale}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{block "title" .}}{{end}}</title>
+</head>
+<body>
+<nav><a href="{{.Prefix}}/members/">Members</a> <a href="{{.Prefix}}/projects/">Projects</a></nav>
+<main>{{block "main" .}}{{end}}</main>
+</body>
+</html>
+`
+
+var listTemplate = template.Must(template.Must(template.New("list").Funcs(funcs).Parse(layout)).Parse(`
+{{define "title"}}{{title .Key}}{{end}}
+{{define "main"}}
+<h1>{{title .Key}}</h1>
+<ul>
+{{range .Items}}<li><a href="{{$.Prefix}}/{{$.Key}}/{{.slug}}/">{{.name}}</a>{{with .client_name}} &middot; {{.}}{{end}}</li>
+{{end}}</ul>
+{{end}}
+`))
+
+var detailTemplate = template.Must(template.Must(template.New("detail").Funcs(funcs).Parse(layout)).Parse(`
+{{define "title"}}{{.Item.name}}{{end}}
+{{define "main"}}
+<h1>{{.Item.name}}</h1>
+{{with .Item.photo}}<img src="{{.}}" alt="{{$.Item.name}}">{{end}}
+{{with .Item.client_name}}<p>{{.}}</p>{{end}}
+{{with .Item.description}}<p>{{.}}</p>{{end}}
+{{with .Item.tags}}<ul class="tags">{{range .}}<li>{{.name}}</li>{{end}}</ul>{{end}}
+{{with .Item.team}}<h2>Team</h2>
+<ul>{{range .}}<li><a href="{{$.Prefix}}/members/{{.slug}}/">{{.name}}</a> &middot; {{.role}}</li>{{end}}</ul>{{end}}
+{{with .Item.projects}}<h2>Projects</h2>
+<ul>{{range .}}<li><a href="{{$.Prefix}}/projects/{{.slug}}/">{{.name}}</a> &middot; {{.role}}</li>{{end}}</ul>{{end}}
+{{with .Item.testimonials}}<h2>Testimonials</h2>
+{{range .}}<blockquote><p>{{.quote}}</p><footer>{{.author_name}}{{with .author_title}}, {{.}}{{end}}</footer></blockquote>{{end}}{{end}}
+{{end}}
+`))
+
+// renderHTML writes a page rendered from API data next to the JSON files
+func (e *Exporter) renderHTML(t *template.Template, name string, data pageData) error {
+	var buf bytes.Buffer
+	err := t.Execute(&buf, data)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return e.write(name, buf.Bytes())
+}
@@ -0,0 +1,17 @@
+package storage
+
+// Image is an image stored by SaveImage
+type Image struct {
+	Key string
+	URL string
+	// Created is set when the blob wasn't stored before. Every upload of the same image shares its content addressed blob,
+	// so only a blob created by this upload may be deleted when whatever was going to reference it isn't saved.
+	Created bool
+}
+
+// ServeFileAPIResponse API response for ServeFile when there is no file to serve
+type ServeFileAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Errors  []string `json:"errors,omitempty"`
+}
@@ -0,0 +1,12 @@
+package storage
+
+import "github.com/pkg/errors"
+
+var (
+	ErrBlobNotFound        = errors.New("file not found")
+	ErrInvalidKey          = errors.New("invalid file key")
+	ErrMissingFile         = errors.New("file is required")
+	ErrFileTooLarge        = errors.New("file is too large")
+	ErrUnsupportedFileType = errors.New("file type is not supported, upload a jpeg, png, gif or webp image")
+	ErrInternalServer      = errors.New("internal server error")
+)
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"path"
+	"strings"
+)
+
+type Handler struct {
+	store BlobStore
+}
+
+func NewHandler(store BlobStore) *Handler {
+	return &Handler{
+		store: store,
+	}
+}
+
+// ServeFile serves a stored blob. Keys are content addressed, so responses are cacheable forever.
+func (h *Handler) ServeFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	blob, err := h.store.Get(c, key)
+	if err != nil {
+		switch errors.Cause(err) {
+		case ErrBlobNotFound, ErrInvalidKey:
+			c.JSON(http.StatusNotFound, &ServeFileAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrBlobNotFound.Error()},
+			})
+		default:
+			logrus.Error("[error while reading blob] ", err)
+			c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		}
+		return
+	}
+	defer blob.Close()
+
+	c.Header("Content-Type", ContentType(key))
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("ETag", `"`+strings.TrimSuffix(path.Base(key), path.Ext(key))+`"`)
+	c.Header("X-Content-Type-Options", "nosniff")
+	http.ServeContent(c.Writer, c.Request, key, blob.ModTime, blob)
+}
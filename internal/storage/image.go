@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// imageExtensions are the accepted image content types, as sniffed from the bytes rather than trusting the client
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// ContentType returns the content type of a stored image by its key
+func ContentType(key string) string {
+	ext := path.Ext(key)
+	for contentType, imageExt := range imageExtensions {
+		if imageExt == ext {
+			return contentType
+		}
+	}
+
+	return "application/octet-stream"
+}
+
+// IsImage tells whether the data sniffs as one of the accepted image types and is well formed enough to strip its metadata
+func IsImage(data []byte) bool {
+	if _, ok := imageExtensions[http.DetectContentType(data)]; !ok {
+		return false
+	}
+
+	_, err := StripMetadata(data)
+	return err == nil
+}
+
+const (
+	// multipartOverhead leaves room for multipart boundaries and the other form fields next to the file
+	multipartOverhead = 1 << 20
+
+	// bodyTooLarge is the error http.MaxBytesReader gives, which parsing the form only passes along as text.
+	// It's how a body without a Content-Length turns out to be too large.
+	bodyTooLarge = "http: request body too large"
+)
+
+// ReadUpload reads a multipart file field, refusing anything larger than maxSize bytes
+func ReadUpload(c *gin.Context, field string, maxSize int64) ([]byte, error) {
+	if c.Request.ContentLength > maxSize+multipartOverhead {
+		return nil, errors.Wrapf(ErrFileTooLarge, "%d bytes request", c.Request.ContentLength)
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+
+	fileHeader, err := c.FormFile(field)
+	if err != nil {
+		if strings.Contains(err.Error(), bodyTooLarge) {
+			return nil, errors.Wrap(ErrFileTooLarge, err.Error())
+		}
+		return nil, errors.Wrap(ErrMissingFile, err.Error())
+	}
+
+	if fileHeader.Size > maxSize {
+		return nil, errors.Wrapf(ErrFileTooLarge, "%d bytes", fileHeader.Size)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer file.Close()
+
+	// Don't trust the reported size either
+	data, err := ioutil.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	if int64(len(data)) > maxSize {
+		return nil, errors.Wrap(ErrFileTooLarge, "file exceeds its reported size")
+	}
+
+	return data, nil
+}
+
+// SaveImage stores an image, stripped of its metadata, under a key derived from its content.
+// Content addressed keys never change content, so they can be cached forever.
+func SaveImage(ctx context.Context, store BlobStore, data []byte) (*Image, error) {
+	ext, ok := imageExtensions[http.DetectContentType(data)]
+	if !ok {
+		return nil, errors.Wrap(ErrUnsupportedFileType, http.DetectContentType(data))
+	}
+
+	data, err := StripMetadata(data)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	key := hash[:2] + "/" + hash + ext
+
+	blob, err := store.Get(ctx, key)
+	if err == nil {
+		blob.Close()
+		return &Image{Key: key, URL: store.URL(key)}, nil
+	}
+
+	if errors.Cause(err) != ErrBlobNotFound {
+		return nil, err
+	}
+
+	err = store.Put(ctx, key, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Image{Key: key, URL: store.URL(key), Created: true}, nil
+}
+
+// DiscardImage deletes an image SaveImage created when the record that was going to reference it couldn't be saved.
+// It runs while handling another error, so failing to delete is only logged.
+func DiscardImage(ctx context.Context, store BlobStore, image *Image) {
+	if !image.Created {
+		return
+	}
+
+	err := store.Delete(ctx, image.Key)
+	if err != nil {
+		logrus.Error("[error while discarding unreferenced image] ", err)
+	}
+}
@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// NewLocalStore stores blobs as files under root, served from baseURL
+func NewLocalStore(root, baseURL string) (BlobStore, error) {
+	err := os.MkdirAll(root, 0755)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &localStore{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+type localStore struct {
+	root    string
+	baseURL string
+}
+
+// Put writes to a temporary file first and renames it into place, so a half written blob is never served
+func (s localStore) Put(ctx context.Context, key string, data io.Reader) error {
+	filename, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(filename), 0755)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(filename), ".upload-*")
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, data)
+	if err != nil {
+		tmp.Close()
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = os.Chmod(tmp.Name(), 0644)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = os.Rename(tmp.Name(), filename)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+func (s localStore) Get(ctx context.Context, key string) (*Blob, error) {
+	filename, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrap(ErrBlobNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	if info.IsDir() {
+		file.Close()
+		return nil, errors.Wrap(ErrBlobNotFound, key+" is a directory")
+	}
+
+	return &Blob{
+		ReadSeekCloser: file,
+		Size:           info.Size(),
+		ModTime:        info.ModTime(),
+	}, nil
+}
+
+func (s localStore) Delete(ctx context.Context, key string) error {
+	filename, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+func (s localStore) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// path maps a key to a file under root, rejecting keys that would escape it
+func (s localStore) path(key string) (string, error) {
+	if key == "" || path.IsAbs(key) || path.Clean(key) != key || strings.HasPrefix(key, "..") || strings.HasPrefix(path.Base(key), ".") {
+		return "", errors.Wrap(ErrInvalidKey, key)
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
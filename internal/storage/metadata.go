@@ -0,0 +1,369 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"net/http"
+)
+
+var (
+	jpegSOI      = []byte{0xFF, 0xD8}
+	pngSignature = []byte("\x89PNG\r\n\x1a\n")
+	exifHeader   = []byte("Exif\x00\x00")
+	riffHeader   = []byte("RIFF")
+	webpHeader   = []byte("WEBP")
+	gifHeaders   = [][]byte{[]byte("GIF87a"), []byte("GIF89a")}
+)
+
+// pngMetadataChunks are ancillary PNG chunks that carry metadata rather than pixels
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"iTXt": true,
+	"zTXt": true,
+	"tIME": true,
+}
+
+// webpMetadataChunks are WebP chunks that carry metadata rather than pixels, with the VP8X flag announcing each
+var webpMetadataChunks = map[string]byte{
+	"EXIF": 0x08,
+	"XMP ": 0x04,
+}
+
+// gifKeptApplications are the GIF application extensions that affect how the image renders, every other one is metadata
+var gifKeptApplications = map[string]bool{
+	"NETSCAPE2.0": true,
+	"ANIMEXTS1.0": true,
+	"ICCRGBG1012": true,
+}
+
+// StripMetadata drops EXIF, XMP, IPTC and comments from images without re-encoding them,
+// so location data from phones never reaches the blob store. A JPEG keeps its EXIF orientation,
+// otherwise it would show up rotated. Images that can't be parsed are refused rather than stored with their metadata.
+func StripMetadata(data []byte) ([]byte, error) {
+	var (
+		stripped []byte
+		ok       bool
+	)
+
+	switch {
+	case bytes.HasPrefix(data, jpegSOI):
+		stripped, ok = stripJPEG(data)
+	case bytes.HasPrefix(data, pngSignature):
+		stripped, ok = stripPNG(data)
+	case bytes.HasPrefix(data, riffHeader) && len(data) >= 12 && bytes.Equal(data[8:12], webpHeader):
+		stripped, ok = stripWebP(data)
+	case bytes.HasPrefix(data, gifHeaders[0]), bytes.HasPrefix(data, gifHeaders[1]):
+		stripped, ok = stripGIF(data)
+	}
+
+	if !ok {
+		return nil, errors.Wrap(ErrUnsupportedFileType, "malformed "+http.DetectContentType(data))
+	}
+
+	return stripped, nil
+}
+
+// JPEGOrientation returns the EXIF orientation of a JPEG image, 1 (upright) when it has none
+func JPEGOrientation(data []byte) int {
+	orientation := 1
+	if !bytes.HasPrefix(data, jpegSOI) {
+		return orientation
+	}
+
+	walkJPEG(data, func(marker byte, segment []byte) {
+		if marker == 0xE1 && bytes.HasPrefix(segment[4:], exifHeader) {
+			if o := exifOrientation(segment[4+len(exifHeader):]); o != 0 {
+				orientation = o
+			}
+		}
+	})
+
+	return orientation
+}
+
+func stripJPEG(data []byte) ([]byte, bool) {
+	orientation := 1
+	out := make([]byte, 0, len(data))
+	out = append(out, jpegSOI...)
+
+	ok := walkJPEG(data, func(marker byte, segment []byte) {
+		switch {
+		case marker == 0xE1:
+			if bytes.HasPrefix(segment[4:], exifHeader) {
+				if o := exifOrientation(segment[4+len(exifHeader):]); o != 0 {
+					orientation = o
+				}
+			}
+		case marker == 0xFE, marker == 0xED, marker >= 0xE3 && marker <= 0xEF && marker != 0xEE:
+			// Comments, IPTC and vendor specific application segments.
+			// APP0 (JFIF), APP2 (ICC profile) and APP14 (Adobe color transform) affect rendering, so they stay.
+		default:
+			out = append(out, segment...)
+		}
+	})
+	if !ok {
+		return nil, false
+	}
+
+	if orientation != 1 {
+		segment := orientationSegment(orientation)
+		out = append(out[:len(jpegSOI)], append(segment, out[len(jpegSOI):]...)...)
+	}
+
+	return out, true
+}
+
+// walkJPEG calls fn with every marker segment up to the image data, and appends the image data as one last segment.
+// segment includes the marker and its length.
+func walkJPEG(data []byte, fn func(marker byte, segment []byte)) bool {
+	i := len(jpegSOI)
+	for i+4 <= len(data) {
+		if data[i] != 0xFF {
+			return false
+		}
+
+		marker := data[i+1]
+		if marker == 0xFF {
+			// Fill byte
+			i++
+			continue
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if length < 2 || i+2+length > len(data) {
+			return false
+		}
+
+		if marker == 0xDA {
+			// Start of scan, everything after it is image data
+			fn(marker, data[i:])
+			return true
+		}
+
+		fn(marker, data[i:i+2+length])
+		i += 2 + length
+	}
+
+	return false
+}
+
+// exifOrientation reads the orientation tag from IFD0 of a TIFF structured EXIF payload, 0 when there is none
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 0
+	}
+
+	count := int(order.Uint16(tiff[offset : offset+2]))
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if orientation < 1 || orientation > 8 {
+				return 0
+			}
+			return orientation
+		}
+	}
+
+	return 0
+}
+
+// orientationSegment builds an APP1 EXIF segment holding nothing but the orientation tag
+func orientationSegment(orientation int) []byte {
+	var tiff bytes.Buffer
+	tiff.WriteString("MM\x00\x2a")
+	binary.Write(&tiff, binary.BigEndian, uint32(8))      // IFD0 offset
+	binary.Write(&tiff, binary.BigEndian, uint16(1))      // entry count
+	binary.Write(&tiff, binary.BigEndian, uint16(0x0112)) // orientation tag
+	binary.Write(&tiff, binary.BigEndian, uint16(3))      // SHORT
+	binary.Write(&tiff, binary.BigEndian, uint32(1))      // value count
+	binary.Write(&tiff, binary.BigEndian, uint16(orientation))
+	binary.Write(&tiff, binary.BigEndian, uint16(0)) // value padding
+	binary.Write(&tiff, binary.BigEndian, uint32(0)) // no next IFD
+
+	payload := append(append([]byte{}, exifHeader...), tiff.Bytes()...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+
+	return append(segment, payload...)
+}
+
+func stripPNG(data []byte) ([]byte, bool) {
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+
+	i := len(pngSignature)
+	for i < len(data) {
+		if i+8 > len(data) {
+			return nil, false
+		}
+
+		length := int(binary.BigEndian.Uint32(data[i : i+4]))
+		end := i + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, false
+		}
+
+		if !pngMetadataChunks[string(data[i+4:i+8])] {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+
+	return out, true
+}
+
+func stripWebP(data []byte) ([]byte, bool) {
+	size := int(binary.LittleEndian.Uint32(data[4:8]))
+	if size < 4 || 8+size > len(data) {
+		return nil, false
+	}
+	data = data[:8+size]
+
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+
+	// The flags of the VP8X chunk have to stop announcing the dropped chunks
+	flagsAt := -1
+	var dropped byte
+
+	i := 12
+	for i < len(data) {
+		if i+8 > len(data) {
+			return nil, false
+		}
+
+		fourCC := string(data[i : i+4])
+		length := int(binary.LittleEndian.Uint32(data[i+4 : i+8]))
+		end := i + 8 + length + length%2
+		if length < 0 || end > len(data) {
+			return nil, false
+		}
+
+		if flag, ok := webpMetadataChunks[fourCC]; ok {
+			dropped |= flag
+		} else {
+			if fourCC == "VP8X" && length > 0 {
+				flagsAt = len(out) + 8
+			}
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+
+	if flagsAt >= 0 {
+		out[flagsAt] &^= dropped
+	}
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+
+	return out, true
+}
+
+func stripGIF(data []byte) ([]byte, bool) {
+	// Header and logical screen descriptor, followed by the global color table if there is one
+	i := 13
+	if i > len(data) {
+		return nil, false
+	}
+	if data[10]&0x80 != 0 {
+		i += 3 << (data[10]&0x07 + 1)
+	}
+	if i > len(data) {
+		return nil, false
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:i]...)
+
+	for i < len(data) {
+		switch data[i] {
+		case 0x21:
+			// Extension: label, then data sub-blocks
+			if i+2 > len(data) {
+				return nil, false
+			}
+
+			end, ok := skipGIFSubBlocks(data, i+2)
+			if !ok {
+				return nil, false
+			}
+
+			keep := true
+			switch data[i+1] {
+			case 0xFE:
+				// Comment
+				keep = false
+			case 0xFF:
+				// Application, identified by its first 11 byte sub-block
+				keep = i+3+11 <= len(data) && data[i+2] == 11 && gifKeptApplications[string(data[i+3:i+3+11])]
+			}
+
+			if keep {
+				out = append(out, data[i:end]...)
+			}
+			i = end
+		case 0x2C:
+			// Image descriptor, optional local color table, LZW minimum code size, then image data sub-blocks
+			start := i
+			if i+10 > len(data) {
+				return nil, false
+			}
+			packed := data[i+9]
+			i += 10
+			if packed&0x80 != 0 {
+				i += 3 << (packed&0x07 + 1)
+			}
+			i++
+
+			end, ok := skipGIFSubBlocks(data, i)
+			if !ok {
+				return nil, false
+			}
+
+			out = append(out, data[start:end]...)
+			i = end
+		case 0x3B:
+			// Trailer, anything after it isn't part of the image
+			return append(out, 0x3B), true
+		default:
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+// skipGIFSubBlocks returns where the data sub-blocks starting at i end, past their zero length terminator
+func skipGIFSubBlocks(data []byte, i int) (int, bool) {
+	for i < len(data) {
+		length := int(data[i])
+		i++
+		if length == 0 {
+			return i, true
+		}
+		i += length
+	}
+
+	return 0, false
+}
@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// BlobStore stores uploaded files by key. Keys are slash separated paths such as "ab/abcdef.jpg".
+type BlobStore interface {
+	Put(ctx context.Context, key string, data io.Reader) error
+	Get(ctx context.Context, key string) (*Blob, error)
+	Delete(ctx context.Context, key string) error
+	// URL is where a stored blob is served from, it's what gets recorded in the database
+	URL(key string) string
+}
+
+// Blob is an open stored file, the caller has to close it
+type Blob struct {
+	io.ReadSeekCloser
+	Size    int64
+	ModTime time.Time
+}
@@ -0,0 +1,56 @@
+package tag
+
+import "github.com/rafimuhammad01/portofolio-api/internal/listquery"
+
+// Tag entity represent tags table in database
+type Tag struct {
+	ID   int    `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+	Slug string `json:"slug" db:"slug"`
+}
+
+// Count is a tag along with how many published projects use it
+type Count struct {
+	Tag
+	ProjectCount int `json:"project_count" db:"project_count"`
+}
+
+type ListTag struct {
+	Tags  []Count `json:"tags"`
+	Count int     `json:"count"`
+	listquery.Page
+}
+
+// listConfig whitelists sort and filter parameters for List
+var listConfig = listquery.Config{
+	Fields: map[string]listquery.Field{
+		"id":            {Column: "id", Type: listquery.TypeInt, Sortable: true},
+		"name":          {Column: "name", Sortable: true, Filterable: true},
+		"slug":          {Column: "slug", Filterable: true},
+		"project_count": {Column: "project_count", Type: listquery.TypeInt, Sortable: true, Filterable: true},
+	},
+	IDField:      "id",
+	DefaultSort:  "name",
+	DefaultLimit: 50,
+	MaxLimit:     200,
+}
+
+// ListTagAPIResponse API response for List
+type ListTagAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *ListTag `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// CreateTagAPIRequest create and update tag request body from client
+type CreateTagAPIRequest struct {
+	Name string `json:"name"`
+}
+
+type CreateTagAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    *Tag     `json:"data,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
@@ -0,0 +1,10 @@
+package tag
+
+import "github.com/pkg/errors"
+
+var (
+	ErrTagNotFound     = errors.New("tag not found")
+	ErrTagAlreadyExist = errors.New("tag already exists")
+	ErrInvalidTagName  = errors.New("name should contain letters or numbers")
+	ErrInternalServer  = errors.New("internal server error")
+)
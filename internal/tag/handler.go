@@ -0,0 +1,168 @@
+package tag
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) GetAllTag(c *gin.Context) {
+	// Input Validation
+	q, errorList := listquery.Parse(c.Request.URL.Query(), listConfig)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ListTagAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.List(q)
+	if err != nil {
+		logrus.Error("[error while using list tag service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &ListTagAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) CreateTag(c *gin.Context) {
+	var requestBody CreateTagAPIRequest
+
+	// Input Validation
+	errorList := bindTagRequest(c, &requestBody)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &CreateTagAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Create(requestBody.Name)
+	if err != nil {
+		h.respondError(c, err, "[error while using create tag service] ")
+		return
+	}
+
+	c.JSON(http.StatusCreated, &CreateTagAPIResponse{
+		Status:  http.StatusCreated,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) UpdateTag(c *gin.Context) {
+	var requestBody CreateTagAPIRequest
+
+	// Input Validation
+	tagID, err := strconv.Atoi(c.Param("id"))
+	errorList := bindTagRequest(c, &requestBody)
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &CreateTagAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Update(tagID, requestBody.Name)
+	if err != nil {
+		h.respondError(c, err, "[error while using update tag service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &CreateTagAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) DeleteTag(c *gin.Context) {
+	tagID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &CreateTagAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  []string{"id should be a number"},
+		})
+		return
+	}
+
+	err = h.service.Delete(tagID)
+	if err != nil {
+		h.respondError(c, err, "[error while using delete tag service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &CreateTagAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+	})
+}
+
+func (h *Handler) respondError(c *gin.Context, err error, logPrefix string) {
+	switch errors.Cause(err) {
+	case ErrTagAlreadyExist, ErrInvalidTagName:
+		c.JSON(http.StatusBadRequest, &CreateTagAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  []string{errors.Cause(err).Error()},
+		})
+	case ErrTagNotFound:
+		c.JSON(http.StatusNotFound, &CreateTagAPIResponse{
+			Status:  http.StatusNotFound,
+			Message: "not found",
+			Errors:  []string{ErrTagNotFound.Error()},
+		})
+	default:
+		logrus.Error(logPrefix, err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+	}
+}
+
+func bindTagRequest(c *gin.Context, requestBody *CreateTagAPIRequest) []string {
+	var errorList []string
+
+	err := c.ShouldBindJSON(requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	if requestBody.Name == "" {
+		errorList = append(errorList, "name is required")
+	}
+
+	if len(requestBody.Name) > 64 {
+		errorList = append(errorList, "name should be less than 64 characters")
+	}
+
+	return errorList
+}
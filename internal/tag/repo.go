@@ -0,0 +1,113 @@
+package tag
+
+import (
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+)
+
+const (
+	// uniqueViolation is the PostgreSQL error code for unique_violation
+	uniqueViolation = "23505"
+
+	// tagCountsFrom counts published projects per tag, so drafts and trashed projects don't leak through counts
+	tagCountsFrom = `(
+		SELECT t.id, t.name, t.slug, COUNT(p.id) AS project_count
+		FROM tags t
+		LEFT JOIN project_tags pt ON pt.tag_id = t.id
+		LEFT JOIN projects p ON p.id = pt.project_id AND p.status = 'published' AND p.deleted_at IS NULL
+		GROUP BY t.id
+	) tag_counts`
+)
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+type Repo interface {
+	List(q *listquery.Query) (*ListTag, error)
+	Create(name, slug string) (*Tag, error)
+	Update(ID int, name, slug string) (*Tag, error)
+	Delete(ID int) error
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func (r repo) List(q *listquery.Query) (*ListTag, error) {
+	tags := ListTag{Tags: []Count{}}
+
+	query, args := q.SelectSQL("id, name, slug, project_count", tagCountsFrom, "")
+	err := r.db.Select(&tags.Tags, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	tags.Page, err = q.Paginate(&tags.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	tags.Total, err = q.Total(r.db, tagCountsFrom, "")
+	if err != nil {
+		return nil, err
+	}
+
+	tags.Count = len(tags.Tags)
+
+	return &tags, nil
+}
+
+func (r repo) Create(name, slug string) (*Tag, error) {
+	var tag Tag
+	err := r.db.Get(&tag, "INSERT INTO tags (name, slug) VALUES ($1, $2) RETURNING id, name, slug", name, slug)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+			return nil, errors.Wrap(ErrTagAlreadyExist, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &tag, nil
+}
+
+func (r repo) Update(ID int, name, slug string) (*Tag, error) {
+	var tag Tag
+	err := r.db.Get(&tag, "UPDATE tags SET name=$1, slug=$2 WHERE id=$3 RETURNING id, name, slug", name, slug, ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrTagNotFound, err.Error())
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+			return nil, errors.Wrap(ErrTagAlreadyExist, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &tag, nil
+}
+
+// Delete removes the tag, project_tags rows go along with it through ON DELETE CASCADE
+func (r repo) Delete(ID int) error {
+	res, err := r.db.Exec("DELETE FROM tags WHERE id=$1", ID)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	if affected == 0 {
+		return errors.Wrap(ErrTagNotFound, "no tags row deleted")
+	}
+
+	return nil
+}
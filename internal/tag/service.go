@@ -0,0 +1,66 @@
+package tag
+
+import (
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+)
+
+func NewService(repo Repo) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+type Service interface {
+	List(q *listquery.Query) (*ListTag, error)
+	Create(name string) (*Tag, error)
+	Update(ID int, name string) (*Tag, error)
+	Delete(ID int) error
+}
+
+type service struct {
+	repo Repo
+}
+
+func (s service) List(q *listquery.Query) (*ListTag, error) {
+	tags, err := s.repo.List(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// Create derives the slug used by ?tag= filters from name, so "Go" and "go" are the same tag
+func (s service) Create(name string) (*Tag, error) {
+	slug := utils.Slugify(name)
+	if slug == "" {
+		return nil, errors.Wrap(ErrInvalidTagName, name)
+	}
+
+	tag, err := s.repo.Create(name, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (s service) Update(ID int, name string) (*Tag, error) {
+	slug := utils.Slugify(name)
+	if slug == "" {
+		return nil, errors.Wrap(ErrInvalidTagName, name)
+	}
+
+	tag, err := s.repo.Update(ID, name, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (s service) Delete(ID int) error {
+	return s.repo.Delete(ID)
+}
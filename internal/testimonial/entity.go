@@ -0,0 +1,81 @@
+package testimonial
+
+import (
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"time"
+)
+
+// Moderation status. Submitted testimonials are pending until approved or rejected,
+// only approved testimonials are shown publicly.
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+// Testimonial entity represent testimonials table in database
+type Testimonial struct {
+	ID          int        `json:"id" db:"id"`
+	ProjectID   int        `json:"project_id" db:"project_id"`
+	ProjectName string     `json:"project_name" db:"project_name"`
+	Quote       string     `json:"quote" db:"quote"`
+	AuthorName  string     `json:"author_name" db:"author_name"`
+	AuthorTitle *string    `json:"author_title" db:"author_title"`
+	Rating      int        `json:"rating" db:"rating"`
+	Status      string     `json:"status" db:"status"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	ModeratedAt *time.Time `json:"moderated_at" db:"moderated_at"`
+}
+
+type ListTestimonial struct {
+	Testimonials []Testimonial `json:"testimonials"`
+	Count        int           `json:"count"`
+	listquery.Page
+}
+
+// listConfig whitelists sort and filter parameters for List
+var listConfig = listquery.Config{
+	Fields: map[string]listquery.Field{
+		"id":         {Column: "id", Type: listquery.TypeInt, Sortable: true},
+		"project_id": {Column: "project_id", Type: listquery.TypeInt, Filterable: true},
+		"rating":     {Column: "rating", Type: listquery.TypeInt, Sortable: true, Filterable: true},
+		"status":     {Column: "status", Filterable: true},
+		"created_at": {Column: "created_at", Type: listquery.TypeTime, Sortable: true},
+	},
+	IDField:      "id",
+	DefaultSort:  "-created_at",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// ListTestimonialAPIResponse API response for List
+type ListTestimonialAPIResponse struct {
+	Status  int              `json:"status"`
+	Message string           `json:"message"`
+	Data    *ListTestimonial `json:"data,omitempty"`
+	Errors  []string         `json:"errors,omitempty"`
+}
+
+// SubmitTestimonialAPIRequest submit testimonial request body from client.
+// Website is a honeypot, it is hidden from humans so only bots fill it in.
+type SubmitTestimonialAPIRequest struct {
+	ProjectID   int     `json:"project_id"`
+	Quote       string  `json:"quote"`
+	AuthorName  string  `json:"author_name"`
+	AuthorTitle *string `json:"author_title"`
+	Rating      int     `json:"rating"`
+	Website     string  `json:"website"`
+}
+
+// ModerateTestimonialAPIRequest approve or reject testimonial request body from client
+type ModerateTestimonialAPIRequest struct {
+	Status string `json:"status"`
+}
+
+// TestimonialAPIResponse API response for Submit, Moderate and Delete
+type TestimonialAPIResponse struct {
+	Status  int          `json:"status"`
+	Message string       `json:"message"`
+	Data    *Testimonial `json:"data,omitempty"`
+	Errors  []string     `json:"errors,omitempty"`
+}
@@ -0,0 +1,11 @@
+package testimonial
+
+import "github.com/pkg/errors"
+
+var (
+	ErrTestimonialNotFound = errors.New("testimonial not found")
+	ErrProjectNotFound     = errors.New("project not found")
+	ErrSpamDetected        = errors.New("submission looks like spam")
+	ErrTooManyRequests     = errors.New("too many testimonials, please try again later")
+	ErrInternalServer      = errors.New("internal server error")
+)
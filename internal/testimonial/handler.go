@@ -0,0 +1,219 @@
+package testimonial
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) GetAllTestimonial(c *gin.Context) {
+	preview, err := utils.IsPreview(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &ListTestimonialAPIResponse{
+			Status:  http.StatusUnauthorized,
+			Message: "unauthorized",
+			Errors:  []string{errors.Cause(err).Error()},
+		})
+		return
+	}
+
+	// Input Validation
+	q, errorList := listquery.Parse(c.Request.URL.Query(), listConfig)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ListTestimonialAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.List(q, preview)
+	if err != nil {
+		logrus.Error("[error while using list testimonial service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &ListTestimonialAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) SubmitTestimonial(c *gin.Context) {
+	var (
+		errorList   []string
+		requestBody SubmitTestimonialAPIRequest
+	)
+
+	// Input Validation
+	err := c.ShouldBindJSON(&requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	requestBody.Quote = strings.TrimSpace(requestBody.Quote)
+	requestBody.AuthorName = strings.TrimSpace(requestBody.AuthorName)
+
+	if requestBody.ProjectID <= 0 {
+		errorList = append(errorList, "project_id is required")
+	}
+
+	if requestBody.Quote == "" {
+		errorList = append(errorList, "quote is required")
+	}
+
+	if len(requestBody.Quote) > 2000 {
+		errorList = append(errorList, "quote should be less than 2000 characters")
+	}
+
+	if requestBody.AuthorName == "" {
+		errorList = append(errorList, "author_name is required")
+	}
+
+	if len(requestBody.AuthorName) > 128 {
+		errorList = append(errorList, "author_name should be less than 128 characters")
+	}
+
+	if requestBody.AuthorTitle != nil && len(*requestBody.AuthorTitle) > 128 {
+		errorList = append(errorList, "author_title should be less than 128 characters")
+	}
+
+	if requestBody.Rating < 1 || requestBody.Rating > 5 {
+		errorList = append(errorList, "rating should be between 1 and 5")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &TestimonialAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Submit(requestBody.ProjectID, requestBody.Quote, requestBody.AuthorName, requestBody.AuthorTitle, requestBody.Rating, requestBody.Website, c.ClientIP(), c)
+	if err != nil {
+		switch errors.Cause(err) {
+		case ErrSpamDetected:
+			// Don't let bots know they were caught
+			logrus.Info("[testimonial dropped] ", err)
+			c.JSON(http.StatusCreated, &TestimonialAPIResponse{
+				Status:  http.StatusCreated,
+				Message: "success",
+			})
+		case ErrTooManyRequests:
+			c.JSON(http.StatusTooManyRequests, &TestimonialAPIResponse{
+				Status:  http.StatusTooManyRequests,
+				Message: "too many requests",
+				Errors:  []string{ErrTooManyRequests.Error()},
+			})
+		default:
+			h.respondError(c, err, "[error while using submit testimonial service] ")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, &TestimonialAPIResponse{
+		Status:  http.StatusCreated,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) ModerateTestimonial(c *gin.Context) {
+	var (
+		errorList   []string
+		requestBody ModerateTestimonialAPIRequest
+	)
+
+	// Input Validation
+	testimonialID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	err = c.ShouldBindJSON(&requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	if requestBody.Status != StatusApproved && requestBody.Status != StatusRejected && requestBody.Status != StatusPending {
+		errorList = append(errorList, "status should be one of approved, rejected or pending")
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &TestimonialAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Moderate(testimonialID, requestBody.Status)
+	if err != nil {
+		h.respondError(c, err, "[error while using moderate testimonial service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &TestimonialAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) DeleteTestimonial(c *gin.Context) {
+	testimonialID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &TestimonialAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  []string{"id should be a number"},
+		})
+		return
+	}
+
+	err = h.service.Delete(testimonialID)
+	if err != nil {
+		h.respondError(c, err, "[error while using delete testimonial service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &TestimonialAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+	})
+}
+
+func (h *Handler) respondError(c *gin.Context, err error, logPrefix string) {
+	switch errors.Cause(err) {
+	case ErrTestimonialNotFound, ErrProjectNotFound:
+		c.JSON(http.StatusNotFound, &TestimonialAPIResponse{
+			Status:  http.StatusNotFound,
+			Message: "not found",
+			Errors:  []string{errors.Cause(err).Error()},
+		})
+	default:
+		logrus.Error(logPrefix, err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+	}
+}
@@ -0,0 +1,160 @@
+package testimonial
+
+import (
+	"context"
+	"database/sql"
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/ratelimit"
+	"time"
+)
+
+const (
+	testimonialColumns = "id, project_id, project_name, quote, author_name, author_title, rating, status, created_at, moderated_at"
+
+	// testimonialsFrom joins the project name in and hides testimonials of trashed projects
+	testimonialsFrom = `(
+		SELECT t.*, p.name AS project_name, p.status AS project_status
+		FROM testimonials t
+		JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+	) testimonials_view`
+
+	// publicCondition only lets approved testimonials of published projects through
+	publicCondition = "status = 'approved' AND project_status = 'published'"
+
+	rateLimitKeyPrefix = "testimonial_rate_limit:"
+)
+
+// NewRepo PostgreSQL for testimonials and Redis for rate limiting
+func NewRepo(db *sqlx.DB, rdb *redis.Client) Repo {
+	return &repo{
+		db:  db,
+		rdb: rdb,
+	}
+}
+
+type Repo interface {
+	CountSubmission(ipAddress string, window time.Duration, ctx context.Context) (int64, error)
+	List(q *listquery.Query, publicOnly bool) (*ListTestimonial, error)
+	GetByID(ID int) (*Testimonial, error)
+	Create(projectID int, quote, authorName string, authorTitle *string, rating int) (*Testimonial, error)
+	UpdateStatus(ID int, status string) (*Testimonial, error)
+	Delete(ID int) error
+}
+
+type repo struct {
+	db  *sqlx.DB
+	rdb *redis.Client
+}
+
+// CountSubmission counts a submission from ipAddress and returns how many were made in the current window
+func (r repo) CountSubmission(ipAddress string, window time.Duration, ctx context.Context) (int64, error) {
+	count, err := ratelimit.Count(r.rdb, rateLimitKeyPrefix+ipAddress, window, ctx)
+	if err != nil {
+		return 0, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return count, nil
+}
+
+func (r repo) List(q *listquery.Query, publicOnly bool) (*ListTestimonial, error) {
+	testimonials := ListTestimonial{Testimonials: []Testimonial{}}
+
+	where := ""
+	if publicOnly {
+		where = publicCondition
+	}
+
+	query, args := q.SelectSQL(testimonialColumns, testimonialsFrom, where)
+	err := r.db.Select(&testimonials.Testimonials, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	testimonials.Page, err = q.Paginate(&testimonials.Testimonials)
+	if err != nil {
+		return nil, err
+	}
+
+	testimonials.Total, err = q.Total(r.db, testimonialsFrom, where)
+	if err != nil {
+		return nil, err
+	}
+
+	testimonials.Count = len(testimonials.Testimonials)
+
+	return &testimonials, nil
+}
+
+func (r repo) GetByID(ID int) (*Testimonial, error) {
+	var testimonial Testimonial
+	err := r.db.Get(&testimonial, "SELECT "+testimonialColumns+" FROM "+testimonialsFrom+" WHERE id=$1", ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrTestimonialNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &testimonial, nil
+}
+
+// Create stores a pending testimonial, it can only be submitted for a published project
+func (r repo) Create(projectID int, quote, authorName string, authorTitle *string, rating int) (*Testimonial, error) {
+	var testimonial Testimonial
+	err := r.db.Get(&testimonial, `
+		WITH inserted AS (
+			INSERT INTO testimonials (project_id, quote, author_name, author_title, rating)
+			SELECT id, $2, $3, $4, $5 FROM projects WHERE id=$1 AND status='published' AND deleted_at IS NULL
+			RETURNING *
+		)
+		SELECT i.id, i.project_id, p.name AS project_name, i.quote, i.author_name, i.author_title, i.rating, i.status, i.created_at, i.moderated_at
+		FROM inserted i
+		JOIN projects p ON p.id = i.project_id`, projectID, quote, authorName, authorTitle, rating)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrProjectNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return &testimonial, nil
+}
+
+func (r repo) UpdateStatus(ID int, status string) (*Testimonial, error) {
+	res, err := r.db.Exec("UPDATE testimonials SET status=$1, moderated_at=NOW() WHERE id=$2", status, ID)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	if count == 0 {
+		return nil, errors.Wrap(ErrTestimonialNotFound, "no testimonial updated")
+	}
+
+	return r.GetByID(ID)
+}
+
+func (r repo) Delete(ID int) error {
+	res, err := r.db.Exec("DELETE FROM testimonials WHERE id=$1", ID)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	if count == 0 {
+		return errors.Wrap(ErrTestimonialNotFound, "no testimonial deleted")
+	}
+
+	return nil
+}
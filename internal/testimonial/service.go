@@ -0,0 +1,80 @@
+package testimonial
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"time"
+)
+
+// Config of the spam checks on submitted testimonials
+type Config struct {
+	// RateLimit is how many testimonials an IP address may submit per RateWindow
+	RateLimit  int64
+	RateWindow time.Duration
+}
+
+func NewService(repo Repo, config Config) Service {
+	return &service{
+		repo:   repo,
+		config: config,
+	}
+}
+
+type Service interface {
+	List(q *listquery.Query, preview bool) (*ListTestimonial, error)
+	Submit(projectID int, quote, authorName string, authorTitle *string, rating int, honeypot, ipAddress string, ctx context.Context) (*Testimonial, error)
+	Moderate(ID int, status string) (*Testimonial, error)
+	Delete(ID int) error
+}
+
+type service struct {
+	repo   Repo
+	config Config
+}
+
+// List returns approved testimonials only, unless previewing where every testimonial is moderated
+func (s service) List(q *listquery.Query, preview bool) (*ListTestimonial, error) {
+	testimonials, err := s.repo.List(q, !preview)
+	if err != nil {
+		return nil, err
+	}
+
+	return testimonials, nil
+}
+
+// Submit stores a pending testimonial after running it through the honeypot and rate limit checks
+func (s service) Submit(projectID int, quote, authorName string, authorTitle *string, rating int, honeypot, ipAddress string, ctx context.Context) (*Testimonial, error) {
+	if honeypot != "" {
+		return nil, errors.Wrap(ErrSpamDetected, "honeypot field is filled in")
+	}
+
+	count, err := s.repo.CountSubmission(ipAddress, s.config.RateWindow, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > s.config.RateLimit {
+		return nil, errors.Wrapf(ErrTooManyRequests, "%d testimonials from %s", count, ipAddress)
+	}
+
+	testimonial, err := s.repo.Create(projectID, quote, authorName, authorTitle, rating)
+	if err != nil {
+		return nil, err
+	}
+
+	return testimonial, nil
+}
+
+func (s service) Moderate(ID int, status string) (*Testimonial, error) {
+	testimonial, err := s.repo.UpdateStatus(ID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return testimonial, nil
+}
+
+func (s service) Delete(ID int) error {
+	return s.repo.Delete(ID)
+}
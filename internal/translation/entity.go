@@ -0,0 +1,94 @@
+package translation
+
+import "github.com/rafimuhammad01/portofolio-api/utils"
+
+// Entity types that have translatable fields
+const (
+	TypeProject = "project"
+	TypeSkill   = "skill"
+	TypeRole    = "role"
+)
+
+// entity describes where an entity type is stored and which of its fields can be translated
+type entity struct {
+	table  string
+	fields []string
+}
+
+var entities = map[string]entity{
+	TypeProject: {table: "projects", fields: []string{"name", "description"}},
+	TypeSkill:   {table: "skills", fields: []string{"skill", "description"}},
+	TypeRole:    {table: "roles", fields: []string{"name", "description"}},
+}
+
+// Row entity represent translations table in database, one translated field of an entity
+type Row struct {
+	EntityID int    `db:"entity_id"`
+	Locale   string `db:"locale"`
+	Field    string `db:"field"`
+	Value    string `db:"value"`
+}
+
+// Translation is every translated field of an entity in one locale
+type Translation struct {
+	Locale string            `json:"locale"`
+	Fields map[string]string `json:"fields"`
+}
+
+// Fields are translated fields of one entity, keyed by field name
+type Fields map[string]string
+
+// Apply replaces *value with the translation of field, if there is one
+func (f Fields) Apply(field string, value *string) {
+	if translated, ok := f[field]; ok {
+		*value = translated
+	}
+}
+
+// ApplyOptional replaces *value with the translation of field, if there is one
+func (f Fields) ApplyOptional(field string, value **string) {
+	if translated, ok := f[field]; ok {
+		*value = &translated
+	}
+}
+
+// ServedLocales are the locales entities end up served in: locale for the ones with a translation, the default locale for the rest
+func ServedLocales(translations map[int]Fields, IDs []int, locale string) []string {
+	translated := 0
+	for _, ID := range IDs {
+		if len(translations[ID]) != 0 {
+			translated++
+		}
+	}
+
+	var locales []string
+	if translated != 0 {
+		locales = append(locales, locale)
+	}
+	if translated != len(IDs) {
+		locales = append(locales, utils.GetDefaultLocale())
+	}
+
+	return locales
+}
+
+// ListTranslationAPIResponse API response for List
+type ListTranslationAPIResponse struct {
+	Status  int           `json:"status"`
+	Message string        `json:"message"`
+	Data    []Translation `json:"data,omitempty"`
+	Errors  []string      `json:"errors,omitempty"`
+}
+
+// SetTranslationAPIRequest set translation request body from client, it replaces every translated field of the locale
+type SetTranslationAPIRequest struct {
+	Fields map[string]string `json:"fields"`
+}
+
+// TranslationAPIResponse API response for Set and Delete
+type TranslationAPIResponse struct {
+	Status  int          `json:"status"`
+	Message string       `json:"message"`
+	Data    *Translation `json:"data,omitempty"`
+	Errors  []string     `json:"errors,omitempty"`
+}
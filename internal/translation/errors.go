@@ -0,0 +1,9 @@
+package translation
+
+import "github.com/pkg/errors"
+
+var (
+	ErrEntityNotFound      = errors.New("entity not found")
+	ErrTranslationNotFound = errors.New("translation not found")
+	ErrInternalServer      = errors.New("internal server error")
+)
@@ -0,0 +1,193 @@
+package translation
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) GetAllTranslation(c *gin.Context) {
+	// Input Validation
+	entityType, entityID, errorList := bindEntity(c)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ListTranslationAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.List(entityType, entityID)
+	if err != nil {
+		if errors.Cause(err) == ErrEntityNotFound {
+			c.JSON(http.StatusNotFound, &ListTranslationAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrEntityNotFound.Error()},
+			})
+			return
+		}
+		logrus.Error("[error while using list translation service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &ListTranslationAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) SetTranslation(c *gin.Context) {
+	var requestBody SetTranslationAPIRequest
+
+	// Input Validation
+	entityType, entityID, errorList := bindEntity(c)
+	locale, localeErrors := bindLocale(c)
+	errorList = append(errorList, localeErrors...)
+
+	err := c.ShouldBindJSON(&requestBody)
+	if err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	if len(requestBody.Fields) == 0 {
+		errorList = append(errorList, "fields is required")
+	}
+
+	fields := map[string]string{}
+	for field, value := range requestBody.Fields {
+		if e, ok := entities[entityType]; ok && !contains(e.fields, field) {
+			errorList = append(errorList, fmt.Sprintf("%s is not translatable, translatable fields are %s", field, strings.Join(e.fields, ", ")))
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if value == "" {
+			errorList = append(errorList, field+" should not be empty")
+			continue
+		}
+		fields[field] = value
+	}
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &TranslationAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.Set(entityType, entityID, locale, fields)
+	if err != nil {
+		h.respondError(c, err, "[error while using set translation service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &TranslationAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+func (h *Handler) DeleteTranslation(c *gin.Context) {
+	// Input Validation
+	entityType, entityID, errorList := bindEntity(c)
+	locale, localeErrors := bindLocale(c)
+	errorList = append(errorList, localeErrors...)
+
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &TranslationAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	err := h.service.Delete(entityType, entityID, locale)
+	if err != nil {
+		h.respondError(c, err, "[error while using delete translation service] ")
+		return
+	}
+
+	c.JSON(http.StatusOK, &TranslationAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+	})
+}
+
+func (h *Handler) respondError(c *gin.Context, err error, logPrefix string) {
+	switch errors.Cause(err) {
+	case ErrEntityNotFound, ErrTranslationNotFound:
+		c.JSON(http.StatusNotFound, &TranslationAPIResponse{
+			Status:  http.StatusNotFound,
+			Message: "not found",
+			Errors:  []string{errors.Cause(err).Error()},
+		})
+	default:
+		logrus.Error(logPrefix, err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+	}
+}
+
+func bindEntity(c *gin.Context) (string, int, []string) {
+	var errorList []string
+
+	entityType := c.Param("type")
+	if _, ok := entities[entityType]; !ok {
+		errorList = append(errorList, "type should be one of project, skill or role")
+	}
+
+	entityID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorList = append(errorList, "id should be a number")
+	}
+
+	return entityType, entityID, errorList
+}
+
+// bindLocale only accepts translated locales, the default locale is edited on the entity itself
+func bindLocale(c *gin.Context) (string, []string) {
+	locale := strings.ToLower(c.Param("locale"))
+	supported := utils.GetSupportedLocales()
+
+	if !utils.IsSupportedLocale(supported, locale) {
+		return locale, []string{"locale should be one of " + strings.Join(supported[1:], ", ")}
+	}
+
+	if locale == utils.GetDefaultLocale() {
+		return locale, []string{locale + " is the default locale, edit the entity itself instead"}
+	}
+
+	return locale, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,102 @@
+package translation
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db: db,
+	}
+}
+
+type Repo interface {
+	EntityExists(entityType string, entityID int) (bool, error)
+	List(entityType string, entityID int) ([]Row, error)
+	Replace(entityType string, entityID int, locale string, fields map[string]string) error
+	Delete(entityType string, entityID int, locale string) error
+	Lookup(entityType string, entityIDs []int, locale string) ([]Row, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func (r repo) EntityExists(entityType string, entityID int) (bool, error) {
+	var exists bool
+	err := r.db.Get(&exists, "SELECT EXISTS (SELECT 1 FROM "+entities[entityType].table+" WHERE id=$1 AND deleted_at IS NULL)", entityID)
+	if err != nil {
+		return false, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return exists, nil
+}
+
+func (r repo) List(entityType string, entityID int) ([]Row, error) {
+	rows := []Row{}
+	err := r.db.Select(&rows, "SELECT entity_id, locale, field, value FROM translations WHERE entity_type=$1 AND entity_id=$2 ORDER BY locale, field", entityType, entityID)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return rows, nil
+}
+
+// Replace swaps every translated field of an entity in locale for fields
+func (r repo) Replace(entityType string, entityID int, locale string, fields map[string]string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM translations WHERE entity_type=$1 AND entity_id=$2 AND locale=$3", entityType, entityID, locale)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	for field, value := range fields {
+		_, err = tx.Exec("INSERT INTO translations (entity_type, entity_id, locale, field, value) VALUES ($1, $2, $3, $4, $5)", entityType, entityID, locale, field, value)
+		if err != nil {
+			return errors.Wrap(ErrInternalServer, err.Error())
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+func (r repo) Delete(entityType string, entityID int, locale string) error {
+	res, err := r.db.Exec("DELETE FROM translations WHERE entity_type=$1 AND entity_id=$2 AND locale=$3", entityType, entityID, locale)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	if count == 0 {
+		return errors.Wrap(ErrTranslationNotFound, "no translation deleted")
+	}
+
+	return nil
+}
+
+func (r repo) Lookup(entityType string, entityIDs []int, locale string) ([]Row, error) {
+	rows := []Row{}
+	err := r.db.Select(&rows, "SELECT entity_id, locale, field, value FROM translations WHERE entity_type=$1 AND entity_id = ANY($2) AND locale=$3", entityType, pq.Array(entityIDs), locale)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,107 @@
+package translation
+
+import (
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+)
+
+func NewService(repo Repo) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+type Service interface {
+	List(entityType string, entityID int) ([]Translation, error)
+	Set(entityType string, entityID int, locale string, fields map[string]string) (*Translation, error)
+	Delete(entityType string, entityID int, locale string) error
+	Lookup(entityType string, entityIDs []int, locale string) (map[int]Fields, error)
+}
+
+type service struct {
+	repo Repo
+}
+
+func (s service) List(entityType string, entityID int) ([]Translation, error) {
+	err := s.checkEntity(entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.List(entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	translations := []Translation{}
+	for _, row := range rows {
+		if len(translations) == 0 || translations[len(translations)-1].Locale != row.Locale {
+			translations = append(translations, Translation{Locale: row.Locale, Fields: map[string]string{}})
+		}
+		translations[len(translations)-1].Fields[row.Field] = row.Value
+	}
+
+	return translations, nil
+}
+
+func (s service) Set(entityType string, entityID int, locale string, fields map[string]string) (*Translation, error) {
+	err := s.checkEntity(entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.Replace(entityType, entityID, locale, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Translation{
+		Locale: locale,
+		Fields: fields,
+	}, nil
+}
+
+func (s service) Delete(entityType string, entityID int, locale string) error {
+	err := s.checkEntity(entityType, entityID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Delete(entityType, entityID, locale)
+}
+
+// Lookup returns the translated fields of entities in locale, keyed by entity id.
+// The default locale is what entities are written in, so it never has translations.
+func (s service) Lookup(entityType string, entityIDs []int, locale string) (map[int]Fields, error) {
+	translations := map[int]Fields{}
+	if locale == utils.GetDefaultLocale() || len(entityIDs) == 0 {
+		return translations, nil
+	}
+
+	rows, err := s.repo.Lookup(entityType, entityIDs, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if translations[row.EntityID] == nil {
+			translations[row.EntityID] = Fields{}
+		}
+		translations[row.EntityID][row.Field] = row.Value
+	}
+
+	return translations, nil
+}
+
+func (s service) checkEntity(entityType string, entityID int) error {
+	exists, err := s.repo.EntityExists(entityType, entityID)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return errors.Wrapf(ErrEntityNotFound, "%s %d", entityType, entityID)
+	}
+
+	return nil
+}
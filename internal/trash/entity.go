@@ -0,0 +1,145 @@
+package trash
+
+import (
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"time"
+)
+
+// Entity types that can be moved to trash
+const (
+	TypeMember  = "member"
+	TypeProject = "project"
+	TypePhoto   = "photo"
+	TypeSkill   = "skill"
+	TypeRole    = "role"
+)
+
+// dependent is a table whose rows are trashed and restored along with their parent
+type dependent struct {
+	table      string
+	foreignKey string
+}
+
+// parent is a table that has to be restored before its children can be
+type parent struct {
+	table      string
+	foreignKey string
+	err        error
+}
+
+// entity describes how an entity type is stored and what moves along with it
+type entity struct {
+	table      string
+	nameColumn string
+	dependents []dependent
+	parent     *parent
+	// links are rows referencing the entity that only get deleted on purge, $1 is the entity id
+	links []string
+	// photos selects the photo URLs that go away on purge, so their stored files can be released
+	photos string
+}
+
+var entities = map[string]entity{
+	TypeMember: {
+		table:      "jastip_members",
+		nameColumn: "name",
+		dependents: []dependent{{table: "skills", foreignKey: "jastip_member_id"}},
+		links: []string{
+			"DELETE FROM translations WHERE entity_type='skill' AND entity_id IN (SELECT id FROM skills WHERE jastip_member_id=$1)",
+			"DELETE FROM revisions WHERE entity_type='skill' AND entity_id IN (SELECT id FROM skills WHERE jastip_member_id=$1)",
+			"DELETE FROM skill_endorsements WHERE skill_id IN (SELECT id FROM skills WHERE jastip_member_id=$1)",
+			"DELETE FROM skills WHERE jastip_member_id=$1",
+			"DELETE FROM jastip_member_roles WHERE jastip_member_id=$1",
+			"DELETE FROM project_members WHERE jastip_member_id=$1",
+			"DELETE FROM slug_history WHERE entity_type='member' AND entity_id=$1",
+			"DELETE FROM revisions WHERE entity_type='member' AND entity_id=$1",
+			"DELETE FROM daily_views WHERE entity_type='member' AND entity_id=$1",
+		},
+		photos: "SELECT photo FROM jastip_members WHERE id=$1 AND photo IS NOT NULL",
+	},
+	TypeProject: {
+		table:      "projects",
+		nameColumn: "name",
+		dependents: []dependent{{table: "project_photos", foreignKey: "project_id"}},
+		links: []string{
+			"DELETE FROM project_photos WHERE project_id=$1",
+			"DELETE FROM project_members WHERE project_id=$1",
+			"DELETE FROM project_tags WHERE project_id=$1",
+			"DELETE FROM testimonials WHERE project_id=$1",
+			"DELETE FROM project_reactions WHERE project_id=$1",
+			"DELETE FROM project_links WHERE project_id=$1",
+			"DELETE FROM slug_history WHERE entity_type='project' AND entity_id=$1",
+			"DELETE FROM translations WHERE entity_type='project' AND entity_id=$1",
+			"DELETE FROM revisions WHERE entity_type='project' AND entity_id=$1",
+			"DELETE FROM daily_views WHERE entity_type='project' AND entity_id=$1",
+		},
+		photos: "SELECT photo FROM project_photos WHERE project_id=$1 AND photo IS NOT NULL",
+	},
+	TypePhoto: {
+		table:      "project_photos",
+		nameColumn: "COALESCE(description, photo, '')",
+		parent:     &parent{table: "projects", foreignKey: "project_id", err: ErrProjectInTrash},
+		photos:     "SELECT photo FROM project_photos WHERE id=$1 AND photo IS NOT NULL",
+	},
+	TypeSkill: {
+		table:      "skills",
+		nameColumn: "skill",
+		parent:     &parent{table: "jastip_members", foreignKey: "jastip_member_id", err: ErrMemberInTrash},
+		links: []string{
+			"DELETE FROM translations WHERE entity_type='skill' AND entity_id=$1",
+			"DELETE FROM revisions WHERE entity_type='skill' AND entity_id=$1",
+			"DELETE FROM skill_endorsements WHERE skill_id=$1",
+		},
+	},
+	TypeRole: {
+		table:      "roles",
+		nameColumn: "name",
+		links: []string{
+			"DELETE FROM jastip_member_roles WHERE role_id=$1",
+			"DELETE FROM translations WHERE entity_type='role' AND entity_id=$1",
+			"DELETE FROM revisions WHERE entity_type='role' AND entity_id=$1",
+		},
+	},
+}
+
+// Item is a soft deleted row of any entity type
+type Item struct {
+	Key       string    `json:"key" db:"key"`
+	Type      string    `json:"type" db:"type"`
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	DeletedAt time.Time `json:"deleted_at" db:"deleted_at"`
+}
+
+type ListItem struct {
+	Items []Item `json:"items"`
+	Count int    `json:"count"`
+	listquery.Page
+}
+
+// listConfig whitelists sort and filter parameters for List
+var listConfig = listquery.Config{
+	Fields: map[string]listquery.Field{
+		"key":        {Column: "key", Sortable: true},
+		"type":       {Column: "type", Filterable: true},
+		"deleted_at": {Column: "deleted_at", Type: listquery.TypeTime, Sortable: true, Filterable: true},
+	},
+	IDField:      "key",
+	DefaultSort:  "-deleted_at",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// ListItemAPIResponse API response for List
+type ListItemAPIResponse struct {
+	Status  int       `json:"status"`
+	Message string    `json:"message"`
+	Data    *ListItem `json:"data,omitempty"`
+	Errors  []string  `json:"errors,omitempty"`
+}
+
+type ItemAPIResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Errors  []string `json:"errors,omitempty"`
+}
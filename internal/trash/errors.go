@@ -0,0 +1,11 @@
+package trash
+
+import "github.com/pkg/errors"
+
+var (
+	ErrItemNotFound   = errors.New("item not found")
+	ErrUnknownType    = errors.New("type should be one of member, project, photo, skill or role")
+	ErrMemberInTrash  = errors.New("restore the member of this skill first")
+	ErrProjectInTrash = errors.New("restore the project of this photo first")
+	ErrInternalServer = errors.New("internal server error")
+)
@@ -0,0 +1,129 @@
+package trash
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/utils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) GetAllItem(c *gin.Context) {
+	// Input Validation
+	q, errorList := listquery.Parse(c.Request.URL.Query(), listConfig)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ListItemAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.List(q)
+	if err != nil {
+		logrus.Error("[error while using list trash service] ", err)
+		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		return
+	}
+
+	c.JSON(http.StatusOK, &ListItemAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+		Data:    res,
+	})
+}
+
+// MoveToTrash creates a handler soft deleting the entityType row identified by the id path param
+func (h *Handler) MoveToTrash(entityType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, &ItemAPIResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+				Errors:  []string{"id should be a number"},
+			})
+			return
+		}
+
+		err = h.service.MoveToTrash(entityType, ID)
+		h.respond(c, err, "[error while using move to trash service] ")
+	}
+}
+
+func (h *Handler) RestoreItem(c *gin.Context) {
+	ID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &ItemAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  []string{"id should be a number"},
+		})
+		return
+	}
+
+	err = h.service.Restore(c.Param("type"), ID)
+	h.respond(c, err, "[error while using restore service] ")
+}
+
+func (h *Handler) PurgeItem(c *gin.Context) {
+	ID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &ItemAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  []string{"id should be a number"},
+		})
+		return
+	}
+
+	err = h.service.Purge(c, c.Param("type"), ID)
+	h.respond(c, err, "[error while using purge service] ")
+}
+
+func (h *Handler) respond(c *gin.Context, err error, logPrefix string) {
+	if err != nil {
+		switch errors.Cause(err) {
+		case ErrUnknownType:
+			c.JSON(http.StatusBadRequest, &ItemAPIResponse{
+				Status:  http.StatusBadRequest,
+				Message: "bad request",
+				Errors:  []string{ErrUnknownType.Error()},
+			})
+		case ErrItemNotFound:
+			c.JSON(http.StatusNotFound, &ItemAPIResponse{
+				Status:  http.StatusNotFound,
+				Message: "not found",
+				Errors:  []string{ErrItemNotFound.Error()},
+			})
+		case ErrMemberInTrash, ErrProjectInTrash:
+			c.JSON(http.StatusConflict, &ItemAPIResponse{
+				Status:  http.StatusConflict,
+				Message: "conflict",
+				Errors:  []string{errors.Cause(err).Error()},
+			})
+		default:
+			logrus.Error(logPrefix, err)
+			c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, &ItemAPIResponse{
+		Status:  http.StatusOK,
+		Message: "success",
+	})
+}
@@ -0,0 +1,214 @@
+package trash
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NewRepo PostgreSQL
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db:        db,
+		trashFrom: trashFrom(),
+	}
+}
+
+type Repo interface {
+	List(q *listquery.Query) (*ListItem, error)
+	MoveToTrash(entityType string, ID int) error
+	Restore(entityType string, ID int) error
+	Purge(entityType string, ID int) ([]string, error)
+}
+
+type repo struct {
+	db        *sqlx.DB
+	trashFrom string
+}
+
+// trashFrom builds a subquery gathering soft deleted rows of every entity type
+func trashFrom() string {
+	var types []string
+	for entityType := range entities {
+		types = append(types, entityType)
+	}
+	sort.Strings(types)
+
+	var selects []string
+	for _, entityType := range types {
+		e := entities[entityType]
+		selects = append(selects, fmt.Sprintf(
+			"SELECT '%s:' || id AS key, '%s' AS type, id, %s AS name, deleted_at FROM %s WHERE deleted_at IS NOT NULL",
+			entityType, entityType, e.nameColumn, e.table,
+		))
+	}
+
+	return "(" + strings.Join(selects, " UNION ALL ") + ") trash"
+}
+
+func (r repo) List(q *listquery.Query) (*ListItem, error) {
+	items := ListItem{Items: []Item{}}
+
+	query, args := q.SelectSQL("key, type, id, name, deleted_at", r.trashFrom, "")
+	err := r.db.Select(&items.Items, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	items.Page, err = q.Paginate(&items.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	items.Total, err = q.Total(r.db, r.trashFrom, "")
+	if err != nil {
+		return nil, err
+	}
+
+	items.Count = len(items.Items)
+
+	return &items, nil
+}
+
+// MoveToTrash soft deletes the row along with its dependents, stamping them all with
+// the same deleted_at so Restore knows which dependents went to trash together
+func (r repo) MoveToTrash(entityType string, ID int) error {
+	e := entities[entityType]
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	var deletedAt time.Time
+	err = tx.Get(&deletedAt, fmt.Sprintf("UPDATE %s SET deleted_at=NOW() WHERE id=$1 AND deleted_at IS NULL RETURNING deleted_at", e.table), ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.Wrap(ErrItemNotFound, err.Error())
+		}
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	for _, d := range e.dependents {
+		_, err = tx.Exec(fmt.Sprintf("UPDATE %s SET deleted_at=$2 WHERE %s=$1 AND deleted_at IS NULL", d.table, d.foreignKey), ID, deletedAt)
+		if err != nil {
+			return errors.Wrap(ErrInternalServer, err.Error())
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+// Restore brings the row back along with the dependents that were trashed with it
+func (r repo) Restore(entityType string, ID int) error {
+	e := entities[entityType]
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	if e.parent != nil {
+		var parentInTrash bool
+		err = tx.Get(&parentInTrash, fmt.Sprintf(`
+			SELECT EXISTS(
+				SELECT 1 FROM %s c JOIN %s p ON p.id = c.%s
+				WHERE c.id=$1 AND p.deleted_at IS NOT NULL
+			)`, e.table, e.parent.table, e.parent.foreignKey), ID)
+		if err != nil {
+			return errors.Wrap(ErrInternalServer, err.Error())
+		}
+
+		if parentInTrash {
+			return errors.Wrap(e.parent.err, "parent is in trash")
+		}
+	}
+
+	var deletedAt time.Time
+	err = tx.Get(&deletedAt, fmt.Sprintf("SELECT deleted_at FROM %s WHERE id=$1 AND deleted_at IS NOT NULL FOR UPDATE", e.table), ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.Wrap(ErrItemNotFound, err.Error())
+		}
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	_, err = tx.Exec(fmt.Sprintf("UPDATE %s SET deleted_at=NULL WHERE id=$1", e.table), ID)
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	for _, d := range e.dependents {
+		_, err = tx.Exec(fmt.Sprintf("UPDATE %s SET deleted_at=NULL WHERE %s=$1 AND deleted_at=$2", d.table, d.foreignKey), ID, deletedAt)
+		if err != nil {
+			return errors.Wrap(ErrInternalServer, err.Error())
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return nil
+}
+
+// Purge permanently deletes a row that is in trash, along with every row referencing it.
+// It returns the photo URLs that were deleted with it.
+func (r repo) Purge(entityType string, ID int) ([]string, error) {
+	e := entities[entityType]
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+	defer tx.Rollback()
+
+	var trashedID int
+	err = tx.Get(&trashedID, fmt.Sprintf("SELECT id FROM %s WHERE id=$1 AND deleted_at IS NOT NULL FOR UPDATE", e.table), ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(ErrItemNotFound, err.Error())
+		}
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	photos := []string{}
+	if e.photos != "" {
+		err = tx.Select(&photos, e.photos, ID)
+		if err != nil {
+			return nil, errors.Wrap(ErrInternalServer, err.Error())
+		}
+	}
+
+	for _, link := range e.links {
+		_, err = tx.Exec(link, ID)
+		if err != nil {
+			return nil, errors.Wrap(ErrInternalServer, err.Error())
+		}
+	}
+
+	_, err = tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE id=$1", e.table), ID)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalServer, err.Error())
+	}
+
+	return photos, nil
+}
@@ -0,0 +1,72 @@
+package trash
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+	"github.com/rafimuhammad01/portofolio-api/internal/media"
+	"github.com/sirupsen/logrus"
+)
+
+func NewService(repo Repo, mediaService media.Service) Service {
+	return &service{
+		repo:         repo,
+		mediaService: mediaService,
+	}
+}
+
+type Service interface {
+	List(q *listquery.Query) (*ListItem, error)
+	MoveToTrash(entityType string, ID int) error
+	Restore(entityType string, ID int) error
+	Purge(ctx context.Context, entityType string, ID int) error
+}
+
+type service struct {
+	repo         Repo
+	mediaService media.Service
+}
+
+func (s service) List(q *listquery.Query) (*ListItem, error) {
+	items, err := s.repo.List(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (s service) MoveToTrash(entityType string, ID int) error {
+	if _, ok := entities[entityType]; !ok {
+		return errors.Wrap(ErrUnknownType, entityType)
+	}
+
+	return s.repo.MoveToTrash(entityType, ID)
+}
+
+func (s service) Restore(entityType string, ID int) error {
+	if _, ok := entities[entityType]; !ok {
+		return errors.Wrap(ErrUnknownType, entityType)
+	}
+
+	return s.repo.Restore(entityType, ID)
+}
+
+func (s service) Purge(ctx context.Context, entityType string, ID int) error {
+	if _, ok := entities[entityType]; !ok {
+		return errors.Wrap(ErrUnknownType, entityType)
+	}
+
+	photos, err := s.repo.Purge(entityType, ID)
+	if err != nil {
+		return err
+	}
+
+	// The purge is done at this point, files left behind get deleted by the media processor later on
+	err = s.mediaService.Release(ctx, photos...)
+	if err != nil {
+		logrus.Error("[error while releasing purged photos] ", err)
+	}
+
+	return nil
+}
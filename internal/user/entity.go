@@ -1,6 +1,9 @@
 package user
 
-import "github.com/rafimuhammad01/portofolio-api/internal/jwt"
+import (
+	"github.com/rafimuhammad01/portofolio-api/internal/jwt"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
+)
 
 // User entity represent users table in database
 type User struct {
@@ -12,6 +15,20 @@ type User struct {
 type ListUser struct {
 	Users []User `json:"users"`
 	Count int    `json:"count"`
+	listquery.Page
+}
+
+// listConfig whitelists sort and filter parameters for List
+var listConfig = listquery.Config{
+	Fields: map[string]listquery.Field{
+		"id":        {Column: "id", Type: listquery.TypeInt, Sortable: true, Filterable: true},
+		"username":  {Column: "username", Sortable: true, Filterable: true},
+		"full_name": {Column: "full_name", Sortable: true, Filterable: true},
+	},
+	IDField:      "id",
+	DefaultSort:  "id",
+	DefaultLimit: 20,
+	MaxLimit:     100,
 }
 
 // ListUserAPIResponse API response for List
@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 	"github.com/rafimuhammad01/portofolio-api/internal/jwt"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
 	"github.com/rafimuhammad01/portofolio-api/utils"
 	"github.com/sirupsen/logrus"
 	"net/http"
@@ -77,14 +78,19 @@ func (h *Handler) Login(c *gin.Context) {
 }
 
 func (h *Handler) GetAllUser(c *gin.Context) {
-	res, err := h.service.List()
+	// Input Validation
+	q, errorList := listquery.Parse(c.Request.URL.Query(), listConfig)
+	if len(errorList) != 0 {
+		c.JSON(http.StatusBadRequest, &ListUserAPIResponse{
+			Status:  http.StatusBadRequest,
+			Message: "bad request",
+			Errors:  errorList,
+		})
+		return
+	}
+
+	res, err := h.service.List(q)
 	if err != nil {
-		if errors.Cause(err) == ErrUserNotFound {
-			res = &ListUser{
-				Users: []User{},
-				Count: 0,
-			}
-		}
 		logrus.Error("[error while using list user service]", err.Error())
 		c.JSON(http.StatusInternalServerError, utils.InternalServerErrorHandler())
 		return
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
 )
 
 // NewRepo PostgreSQL
@@ -14,7 +15,7 @@ func NewRepo(db *sqlx.DB) Repo {
 }
 
 type Repo interface {
-	List() (*ListUser, error)
+	List(q *listquery.Query) (*ListUser, error)
 	Create(username, fullName, password string) (*User, error)
 	GetByID(ID int) (*User, error)
 	GetByUsername(username string) (*User, error)
@@ -25,17 +26,25 @@ type repo struct {
 	db *sqlx.DB
 }
 
-func (r repo) List() (*ListUser, error) {
-	var users ListUser
+func (r repo) List(q *listquery.Query) (*ListUser, error) {
+	users := ListUser{Users: []User{}}
 
-	err := r.db.Select(&users.Users, "SELECT id, username, full_name FROM users")
+	query, args := q.SelectSQL("id, username, full_name", "users", "")
+	err := r.db.Select(&users.Users, query, args...)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return &users, nil
-		}
 		return nil, errors.Wrap(ErrInternalServer, err.Error())
 	}
 
+	users.Page, err = q.Paginate(&users.Users)
+	if err != nil {
+		return nil, err
+	}
+
+	users.Total, err = q.Total(r.db, "users", "")
+	if err != nil {
+		return nil, err
+	}
+
 	users.Count = len(users.Users)
 
 	return &users, nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/pkg/errors"
 	"github.com/rafimuhammad01/portofolio-api/internal/jwt"
+	"github.com/rafimuhammad01/portofolio-api/internal/listquery"
 	"github.com/rafimuhammad01/portofolio-api/utils"
 	"golang.org/x/crypto/bcrypt"
 	"time"
@@ -17,7 +18,7 @@ func NewService(repo Repo, jwtService jwt.Service) Service {
 }
 
 type Service interface {
-	List() (*ListUser, error)
+	List(q *listquery.Query) (*ListUser, error)
 	Create(username, fullName, password string) (*User, error)
 	Get(ID int) (*User, error)
 	Login(username, password string, ctx context.Context) (string, string, time.Time, error)
@@ -29,8 +30,8 @@ type service struct {
 	jwtService jwt.Service
 }
 
-func (s service) List() (*ListUser, error) {
-	users, err := s.repo.List()
+func (s service) List(q *listquery.Query) (*ListUser, error) {
+	users, err := s.repo.List(q)
 	if err != nil {
 		return nil, err
 	}
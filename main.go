@@ -1,9 +1,12 @@
 package main
 
 import (
+	"flag"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"github.com/rafimuhammad01/portofolio-api/api"
+	"github.com/rafimuhammad01/portofolio-api/internal/static"
+	"github.com/rafimuhammad01/portofolio-api/utils"
 	"github.com/sirupsen/logrus"
 	"os"
 )
@@ -15,6 +18,11 @@ func main() {
 		logrus.Fatal(".env not found, will use default env")
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "export-static" {
+		exportStatic(os.Args[2:])
+		return
+	}
+
 	// Creating router
 	router := gin.Default()
 	s := api.NewServer(router)
@@ -22,4 +30,29 @@ func main() {
 
 	// Running server
 	s.RunServer(os.Getenv("PORT"))
-}
\ No newline at end of file
+}
+
+// exportStatic pre-renders the public portfolio into a directory that can be served from a CDN
+func exportStatic(args []string) {
+	flags := flag.NewFlagSet("export-static", flag.ExitOnError)
+	outDir := flags.String("out", "./static", "directory to write the site to")
+	html := flags.Bool("html", false, "render HTML pages for members and projects next to the JSON")
+	flags.Parse(args)
+
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	s := api.NewServer(router)
+	s.InitRoutes()
+
+	res, err := static.NewExporter(router, static.Config{
+		OutDir:       *outDir,
+		HTML:         *html,
+		Locales:      utils.GetSupportedLocales(),
+		MediaBaseURL: utils.GetEnv("MEDIA_BASE_URL", "/api/v1/media"),
+	}).Run()
+	if err != nil {
+		logrus.Fatal("error exporting static site: ", err)
+	}
+
+	logrus.Infof("static site exported to %s: %d written, %d unchanged, %d removed", *outDir, res.Written, res.Unchanged, res.Removed)
+}
@@ -46,3 +46,18 @@ func AuthMiddleware(tokenMaker *jwt.Handler) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// OptionalAuthMiddleware authorizes the request only when an authorization header is sent,
+// so public endpoints can still tell authenticated editors apart from anonymous visitors
+func OptionalAuthMiddleware(tokenMaker *jwt.Handler) gin.HandlerFunc {
+	authMiddleware := AuthMiddleware(tokenMaker)
+
+	return func(ctx *gin.Context) {
+		if len(ctx.GetHeader(utils.AuthorizationHeaderKey)) == 0 {
+			ctx.Next()
+			return
+		}
+
+		authMiddleware(ctx)
+	}
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetDurationEnv parses a duration from env var key, falling back to defaultValue when it's unset or invalid
+func GetDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	duration, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || duration <= 0 {
+		return defaultValue
+	}
+
+	return duration
+}
+
+func GetProjectPublishInterval() time.Duration {
+	return GetDurationEnv("PROJECT_PUBLISH_INTERVAL", time.Minute)
+}
+
+// GetEnv returns env var key, falling back to defaultValue when it's unset
+func GetEnv(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
+// GetIntEnv parses an integer from env var key, falling back to defaultValue when it's unset or invalid
+func GetIntEnv(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+
+	return value
+}
+
+// GetBoolEnv parses a boolean from env var key, falling back to defaultValue when it's unset or invalid
+func GetBoolEnv(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+// GetMaxUploadSize is the largest file in bytes accepted by upload endpoints
+func GetMaxUploadSize() int64 {
+	return int64(GetIntEnv("MEDIA_MAX_UPLOAD_SIZE", 5<<20))
+}
+
+// GetMaxImportSize is the largest portfolio archive in bytes accepted by the import endpoint
+func GetMaxImportSize() int64 {
+	return int64(GetIntEnv("ARCHIVE_MAX_IMPORT_SIZE", 100<<20))
+}
+
+// GetTrustedProxies lists the proxies, as IPs or CIDRs, whose X-Forwarded-For header is believed for the client IP.
+// None are trusted by default, so the client IP is the address the request came from.
+func GetTrustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+
+	return proxies
+}
@@ -4,10 +4,12 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 	"github.com/rafimuhammad01/portofolio-api/internal/jwt"
+	"strconv"
 )
 
 var (
-	ErrAssertion = errors.New("failed to assert context to payload")
+	ErrAssertion               = errors.New("failed to assert context to payload")
+	ErrPreviewNotAuthenticated = errors.New("preview is only available to authenticated users")
 )
 
 func GetPayloadFromContext(c *gin.Context) (*jwt.Payload, error) {
@@ -20,3 +22,18 @@ func GetPayloadFromContext(c *gin.Context) (*jwt.Payload, error) {
 
 	return payload, nil
 }
+
+// IsPreview reports whether the request asked for unpublished content with ?preview=true.
+// Preview requires the request to be authenticated, see middleware.OptionalAuthMiddleware.
+func IsPreview(c *gin.Context) (bool, error) {
+	preview, _ := strconv.ParseBool(c.Query("preview"))
+	if !preview {
+		return false, nil
+	}
+
+	if _, authenticated := c.Get(AuthorizationPayloadKey); !authenticated {
+		return false, errors.Wrap(ErrPreviewNotAuthenticated, "no authorization payload in context")
+	}
+
+	return true, nil
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"github.com/gin-gonic/gin"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GetDefaultLocale is the locale entities' own columns are written in, it's served when no translation matches
+func GetDefaultLocale() string {
+	return strings.ToLower(GetEnv("DEFAULT_LOCALE", "en"))
+}
+
+// GetSupportedLocales are the locales content can be translated to and served in, the default locale included
+func GetSupportedLocales() []string {
+	locales := []string{GetDefaultLocale()}
+	for _, locale := range strings.Split(GetEnv("SUPPORTED_LOCALES", "en,id"), ",") {
+		locale = strings.ToLower(strings.TrimSpace(locale))
+		if locale != "" && !IsSupportedLocale(locales, locale) {
+			locales = append(locales, locale)
+		}
+	}
+
+	return locales
+}
+
+func IsSupportedLocale(supported []string, locale string) bool {
+	for _, s := range supported {
+		if s == locale {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResolveLocale picks the locale to serve from ?lang= or else Accept-Language, falling back to the default locale.
+// Content missing a translation is still served in the default locale, so the handler reports what was actually
+// served with SetContentLanguage.
+func ResolveLocale(c *gin.Context) string {
+	supported := GetSupportedLocales()
+	locale := matchLocale(supported, c.Query("lang"))
+	if locale == "" {
+		for _, preferred := range parseAcceptLanguage(c.GetHeader("Accept-Language")) {
+			if locale = matchLocale(supported, preferred); locale != "" {
+				break
+			}
+		}
+	}
+
+	if locale == "" {
+		locale = supported[0]
+	}
+
+	c.Header("Vary", "Accept-Language")
+
+	return locale
+}
+
+// SetContentLanguage reports the locales content was served in, the default locale when there was nothing to translate
+func SetContentLanguage(c *gin.Context, locales []string) {
+	var served []string
+	for _, locale := range locales {
+		if !IsSupportedLocale(served, locale) {
+			served = append(served, locale)
+		}
+	}
+
+	if len(served) == 0 {
+		served = []string{GetDefaultLocale()}
+	}
+
+	c.Header("Content-Language", strings.Join(served, ", "))
+}
+
+// matchLocale matches a language tag such as "id-ID" to a supported locale, by its primary language if need be
+func matchLocale(supported []string, tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return ""
+	}
+
+	if IsSupportedLocale(supported, tag) {
+		return tag
+	}
+
+	language := strings.SplitN(tag, "-", 2)[0]
+	if IsSupportedLocale(supported, language) {
+		return language
+	}
+
+	return ""
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header, most preferred first
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag    string
+		weight float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		weight := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					weight = q
+				}
+			}
+		}
+
+		if weight > 0 {
+			tags = append(tags, weighted{tag: tag, weight: weight})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].weight > tags[j].weight
+	})
+
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = tag.tag
+	}
+
+	return result
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"golang.org/x/text/unicode/norm"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const maxSlugLength = 100
+
+// slugTransliterator handles letters that unicode decomposition can't reduce to ASCII
+var slugTransliterator = strings.NewReplacer(
+	"ß", "ss", "æ", "ae", "Æ", "ae", "œ", "oe", "Œ", "oe",
+	"ø", "o", "Ø", "o", "đ", "d", "Đ", "d", "ð", "d", "Ð", "d",
+	"ł", "l", "Ł", "l", "þ", "th", "Þ", "th", "ı", "i",
+	"&", " and ",
+)
+
+// symbolSuffix matches the symbols ending names like C++, C# and A+, which would otherwise all slugify to the bare letter
+var symbolSuffix = regexp.MustCompile(`([\p{L}\p{N}])(\++|#)`)
+
+// symbolSpelling spells out the symbols matched by symbolSuffix
+var symbolSpelling = strings.NewReplacer("+", " plus ", "#", " sharp ")
+
+// Slugify turns a name into a lowercase, dash separated ASCII slug
+func Slugify(name string) string {
+	name = symbolSuffix.ReplaceAllStringFunc(name, symbolSpelling.Replace)
+	name = norm.NFKD.String(slugTransliterator.Replace(name))
+
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			dash = false
+		case unicode.Is(unicode.Mn, r):
+			// drop accents left over by decomposition
+		case b.Len() != 0 && !dash:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+	}
+
+	return strings.Trim(slug, "-")
+}
+
+// SlugRedirectLocation rewrites the last occurrence of oldSlug in the request path to newSlug, keeping the query string
+func SlugRedirectLocation(u *url.URL, oldSlug, newSlug string) string {
+	location := *u
+	if i := strings.LastIndex(location.Path, oldSlug); i >= 0 {
+		location.Path = location.Path[:i] + newSlug + location.Path[i+len(oldSlug):]
+	}
+	location.RawPath = ""
+
+	return location.RequestURI()
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+)
+
+// GetSiteName is the name the portfolio goes by in feeds and other documents handed out to the outside world
+func GetSiteName() string {
+	return GetEnv("SITE_NAME", "Portfolio")
+}
+
+// GetSiteURL is the public address of the portfolio website, without a trailing slash
+func GetSiteURL() string {
+	return strings.TrimSuffix(GetEnv("SITE_URL", "http://localhost:3000"), "/")
+}
+
+// GetAPIURL is the public address of this API, without a trailing slash
+func GetAPIURL() string {
+	return strings.TrimSuffix(GetEnv("API_URL", "http://localhost:8080"), "/")
+}
+
+// AbsoluteURL resolves ref, such as the relative URL of an uploaded file, against base
+func AbsoluteURL(base, ref string) string {
+	baseURL, err := url.Parse(base + "/")
+	if err != nil {
+		return ref
+	}
+
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+
+	return baseURL.ResolveReference(refURL).String()
+}
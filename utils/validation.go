@@ -0,0 +1,32 @@
+package utils
+
+// ValidateReorderIDs checks a reorder request body, bindErr being the error from binding it.
+// IDs must be read after binding, a body that failed to bind is reported alone since its IDs can't be trusted.
+func ValidateReorderIDs(bindErr error, IDs []int) []string {
+	if bindErr != nil {
+		return []string{bindErr.Error()}
+	}
+
+	var errorList []string
+	if len(IDs) == 0 {
+		errorList = append(errorList, "ids is required")
+	}
+
+	if hasDuplicateIDs(IDs) {
+		errorList = append(errorList, "ids should not contain duplicates")
+	}
+
+	return errorList
+}
+
+func hasDuplicateIDs(IDs []int) bool {
+	seen := make(map[int]bool, len(IDs))
+	for _, ID := range IDs {
+		if seen[ID] {
+			return true
+		}
+		seen[ID] = true
+	}
+
+	return false
+}